@@ -0,0 +1,54 @@
+package sortedmap
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+type number interface {
+	constraints.Integer | constraints.Float
+}
+
+// update replaces the value of key with f(value) under the write lock, missing keys starting from zero. It returns the
+// value stored for key after the call, which is the previous one if the new value was rejected.
+func update[K constraints.Ordered, T number](sm *SortedMap[K, T], key K, f func(T) T) T {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	old := sm.items[key]
+	value := f(old)
+
+	if err := sm.set(key, value); err != nil {
+		return old
+	}
+
+	return value
+}
+
+// AddTo adds delta to the value of key, missing keys starting from zero, and returns the new value.
+func AddTo[K constraints.Ordered, T number](sm *SortedMap[K, T], key K, delta T) T {
+	return update(sm, key, func(value T) T {
+		return value + delta
+	})
+}
+
+// SubFrom subtracts delta from the value of key, missing keys starting from zero, and returns the new value.
+func SubFrom[K constraints.Ordered, T number](sm *SortedMap[K, T], key K, delta T) T {
+	return update(sm, key, func(value T) T {
+		return value - delta
+	})
+}
+
+// MultiplyBy multiplies the value of key by factor, missing keys starting from zero, and returns the new value.
+func MultiplyBy[K constraints.Ordered, T number](sm *SortedMap[K, T], key K, factor T) T {
+	return update(sm, key, func(value T) T {
+		return value * factor
+	})
+}
+
+// DivideBy divides the value of key by divisor, missing keys starting from zero, and returns the new value. Integer
+// division by zero panics, like the division operator does.
+func DivideBy[K constraints.Ordered, T number](sm *SortedMap[K, T], key K, divisor T) T {
+	return update(sm, key, func(value T) T {
+		return value / divisor
+	})
+}
@@ -0,0 +1,61 @@
+package sortedmap_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddTo(t *testing.T) {
+	sm := sortedmap.New[string, int]()
+
+	assert.Equal(t, 5, sortedmap.AddTo(sm, "key1", 5))
+	assert.Equal(t, 8, sortedmap.AddTo(sm, "key1", 3))
+	assert.Equal(t, 6, sortedmap.SubFrom(sm, "key1", 2))
+	assert.Equal(t, 18, sortedmap.MultiplyBy(sm, "key1", 3))
+	assert.Equal(t, 4, sortedmap.DivideBy(sm, "key1", 4))
+	assert.Equal(t, -1, sortedmap.SubFrom(sm, "key2", 1))
+	assert.Equal(t, 0, sortedmap.MultiplyBy(sm, "key3", 2))
+
+	assert.Equal(t, []int{4, -1, 0}, sm.Values())
+
+	assert.Panics(t, func() {
+		sortedmap.DivideBy(sm, "key1", 0)
+	})
+}
+
+func TestAddTo_Concurrent(t *testing.T) {
+	var wg sync.WaitGroup
+
+	sm := sortedmap.New[string, float64]()
+
+	for range 100 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			sortedmap.AddTo(sm, "total", 0.5)
+		}()
+	}
+
+	wg.Wait()
+
+	assert.InDelta(t, 50.0, sm.MustGet("total"), 1e-9)
+}
+
+func TestAddTo_Rejected(t *testing.T) {
+	sm := sortedmap.New(sortedmap.WithValidator(func(_ string, value int) error {
+		if value > 10 {
+			return errors.New("too large")
+		}
+
+		return nil
+	}))
+
+	assert.Equal(t, 8, sortedmap.AddTo(sm, "key1", 8))
+	assert.Equal(t, 8, sortedmap.AddTo(sm, "key1", 8))
+}
@@ -0,0 +1,231 @@
+package sortedmap
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"golang.org/x/exp/constraints"
+)
+
+// unlocked returns a map sharing the data of sm, but using its own lock. It must be used while the write lock of sm is
+// held and its changes must be adopted back by sm.
+func (sm *SortedMap[K, T]) unlocked() *SortedMap[K, T] {
+	draft := &SortedMap[K, T]{
+		mu:            &sync.RWMutex{},
+		items:         sm.items,
+		sortedKeys:    sm.sortedKeys,
+		validators:    sm.validators,
+		maxSize:       sm.maxSize,
+		policy:        sm.policy,
+		onEvict:       sm.onEvict,
+		observers:     sm.observers,
+		observerNames: sm.observerNames,
+		direction:     sm.direction,
+		waiters:       sm.waiters,
+	}
+
+	draft.reads.Store(sm.reads.Load())
+
+	return draft
+}
+
+func (sm *SortedMap[K, T]) adopt(draft *SortedMap[K, T]) {
+	sm.items = draft.items
+	sm.sortedKeys = draft.sortedKeys
+	sm.observers = draft.observers
+	sm.observerNames = draft.observerNames
+
+	sm.version.Add(draft.version.Load())
+}
+
+// BatchUpdate calls f with a draft of the map while holding the write lock of the map once, changes made to the draft
+// are applied to the map even if f panics. Readers of the map are blocked until f returns, and f must only use the
+// draft, not the map itself.
+func (sm *SortedMap[K, T]) BatchUpdate(f func(draft *SortedMap[K, T])) *SortedMap[K, T] {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	draft := sm.unlocked()
+	defer sm.adopt(draft)
+
+	f(draft)
+
+	return sm
+}
+
+type OpKind int
+
+const (
+	OpSet OpKind = iota
+	OpDelete
+)
+
+// Op is a modification of a map, Value is ignored for OpDelete.
+type Op[K constraints.Ordered, T any] struct {
+	Kind  OpKind
+	Key   K
+	Value T
+}
+
+var ErrUnknownOpKind = errors.New("unknown operation kind")
+
+// undoEntry is the state of a key before a batch first changed it.
+type undoEntry[T any] struct {
+	value   T
+	existed bool
+}
+
+// opRecorder records the notifications and evictions of a batch so that they can be replayed once the batch
+// succeeded, and the previous state of the changed keys so that the batch can be rolled back.
+type opRecorder[K constraints.Ordered, T any] struct {
+	observers     map[string]Observer[K, T]
+	observerNames []string
+	onEvict       func(K, T)
+	replay        []func()
+	undo          map[K]undoEntry[T]
+}
+
+func (r *opRecorder[K, T]) touch(key K, old T, oldExists bool) {
+	if _, exists := r.undo[key]; !exists {
+		r.undo[key] = undoEntry[T]{value: old, existed: oldExists}
+	}
+}
+
+func (r *opRecorder[K, T]) OnSet(key K, old T, oldExists bool, new T) {
+	r.touch(key, old, oldExists)
+
+	r.replay = append(r.replay, func() {
+		for _, name := range r.observerNames {
+			r.observers[name].OnSet(key, old, oldExists, new)
+		}
+	})
+}
+
+func (r *opRecorder[K, T]) OnDelete(key K, value T) {
+	r.touch(key, value, true)
+
+	r.replay = append(r.replay, func() {
+		for _, name := range r.observerNames {
+			r.observers[name].OnDelete(key, value)
+		}
+	})
+}
+
+func (r *opRecorder[K, T]) OnEvict(key K, value T) {
+	r.replay = append(r.replay, func() {
+		r.onEvict(key, value)
+	})
+}
+
+// rollback restores the keys changed in draft to their state before the batch.
+func (r *opRecorder[K, T]) rollback(draft *SortedMap[K, T]) {
+	for key, entry := range r.undo {
+		exists := draft.has(key)
+
+		switch {
+		case entry.existed:
+			draft.items[key] = entry.value
+
+			if !exists {
+				draft.sortedKeys = insertSorted(draft.sortedKeys, key, draft.direction)
+			}
+		case exists:
+			delete(draft.items, key)
+
+			draft.sortedKeys = deleteSorted(draft.sortedKeys, key, draft.direction)
+		}
+	}
+}
+
+// applyBatch applies ops in order and returns the index of the failing operation with its error, rolling back all the
+// changes in that case. Observers, the eviction callback and the goroutines waiting for keys are only notified, and
+// the version is only increased, once all operations succeeded. It must be called while the write lock is held.
+func (sm *SortedMap[K, T]) applyBatch(ops []Op[K, T]) (int, error) {
+	recorder := &opRecorder[K, T]{
+		observers:     sm.observers,
+		observerNames: sm.observerNames,
+		onEvict:       sm.onEvict,
+		undo:          make(map[K]undoEntry[T]),
+	}
+
+	draft := sm.unlocked()
+	draft.observers = map[string]Observer[K, T]{"": recorder}
+	draft.observerNames = []string{""}
+	draft.waiters = nil
+
+	if sm.onEvict != nil {
+		draft.onEvict = recorder.OnEvict
+	}
+
+	committed := false
+
+	defer func() {
+		if !committed {
+			recorder.rollback(draft)
+
+			sm.items, sm.sortedKeys = draft.items, draft.sortedKeys
+		}
+	}()
+
+	for i, op := range ops {
+		var err error
+
+		switch op.Kind {
+		case OpSet:
+			err = draft.set(op.Key, op.Value)
+		case OpDelete:
+			draft.delete(op.Key)
+		default:
+			err = ErrUnknownOpKind
+		}
+
+		if err != nil {
+			return i, err
+		}
+	}
+
+	committed = true
+
+	sm.items, sm.sortedKeys = draft.items, draft.sortedKeys
+
+	sm.version.Add(draft.version.Load())
+
+	for _, replay := range recorder.replay {
+		replay()
+	}
+
+	for key := range recorder.undo {
+		if sm.has(key) {
+			sm.wake(key)
+		}
+	}
+
+	return len(ops), nil
+}
+
+// setAll sets all entries or none of them, it must be called while the write lock is held.
+func (sm *SortedMap[K, T]) setAll(entries []Entry[K, T]) error {
+	ops := make([]Op[K, T], 0, len(entries))
+	for _, entry := range entries {
+		ops = append(ops, Op[K, T]{Kind: OpSet, Key: entry.Key, Value: entry.Value})
+	}
+
+	_, err := sm.applyBatch(ops)
+
+	return err
+}
+
+// ApplyBatch applies ops in order while holding the write lock of the map once. If any of the operations fails, all
+// the changes are rolled back and the error is returned. Observers, the eviction callback and the goroutines waiting
+// for keys are only notified, and the version of the map only changes, once all operations succeeded.
+func (sm *SortedMap[K, T]) ApplyBatch(ops []Op[K, T]) (*SortedMap[K, T], error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if i, err := sm.applyBatch(ops); err != nil {
+		return sm, fmt.Errorf("op %d: %w", i, err)
+	}
+
+	return sm, nil
+}
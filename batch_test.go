@@ -0,0 +1,191 @@
+package sortedmap_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortedMap_BatchUpdate(t *testing.T) {
+	sm := sortedmap.New[int, int]().
+		Set(-1, -1)
+
+	sm.BatchUpdate(func(draft *sortedmap.SortedMap[int, int]) {
+		for i := range 1000 {
+			draft.Set(i, i*i)
+		}
+
+		draft.Delete(-1)
+	})
+
+	assert.Equal(t, 1000, sm.Len())
+	assert.Equal(t, 0, sm.Keys()[0])
+	assert.Equal(t, 998001, sm.MustGet(999))
+	assert.Equal(t, uint64(1002), sm.Version())
+}
+
+func TestSortedMap_BatchUpdate_Panic(t *testing.T) {
+	sm := sortedmap.New[string, int]()
+
+	assert.Panics(t, func() {
+		sm.BatchUpdate(func(draft *sortedmap.SortedMap[string, int]) {
+			draft.Set("key2", 2).Set("key1", 1)
+
+			panic("oops")
+		})
+	})
+
+	sm.Set("key3", 3)
+
+	assert.Equal(t, []string{"key1", "key2", "key3"}, sm.Keys())
+}
+
+func TestSortedMap_ApplyBatch(t *testing.T) {
+	var events []string
+
+	sm := sortedmap.New[string, int]().
+		Set("key1", 1).
+		Set("key2", 2)
+
+	sm.Register("audit", recordingObserver{name: "audit", events: &events})
+
+	_, err := sm.ApplyBatch([]sortedmap.Op[string, int]{
+		{Kind: sortedmap.OpSet, Key: "key3", Value: 3},
+		{Kind: sortedmap.OpDelete, Key: "key1"},
+		{Kind: sortedmap.OpSet, Key: "key2", Value: 20},
+		{Kind: sortedmap.OpDelete, Key: "nope"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"key2", "key3"}, sm.Keys())
+	assert.Equal(t, []int{20, 3}, sm.Values())
+	assert.Equal(t, []string{
+		"audit: set key3 0 false 3",
+		"audit: delete key1 1",
+		"audit: set key2 2 true 20",
+	}, events)
+}
+
+func TestSortedMap_ApplyBatch_Rollback(t *testing.T) {
+	errEmptyKey := errors.New("empty key")
+
+	var events []string
+
+	sm := sortedmap.New(sortedmap.WithValidator(func(key string, _ int) error {
+		if key == "" {
+			return errEmptyKey
+		}
+
+		return nil
+	})).
+		Set("key1", 1)
+
+	sm.Register("audit", recordingObserver{name: "audit", events: &events})
+
+	_, err := sm.ApplyBatch([]sortedmap.Op[string, int]{
+		{Kind: sortedmap.OpDelete, Key: "key1"},
+		{Kind: sortedmap.OpSet, Key: "key2", Value: 2},
+		{Kind: sortedmap.OpSet, Key: "", Value: 3},
+	})
+	assert.ErrorIs(t, err, errEmptyKey)
+	assert.EqualError(t, err, "op 2: empty key")
+
+	assert.Equal(t, []string{"key1"}, sm.Keys())
+	assert.Empty(t, events)
+
+	_, err = sm.ApplyBatch([]sortedmap.Op[string, int]{{Kind: 42}})
+	assert.ErrorIs(t, err, sortedmap.ErrUnknownOpKind)
+}
+
+func TestSortedMap_ApplyBatch_RollbackEviction(t *testing.T) {
+	var evicted []int
+
+	sm := sortedmap.New(
+		sortedmap.WithMaxSize[int, int](2, func(key, _ int) {
+			evicted = append(evicted, key)
+		}),
+		sortedmap.WithEvictionPolicy[int, int](sortedmap.EvictSmallest),
+		sortedmap.WithValidator(func(_, value int) error {
+			if value < 0 {
+				return errors.New("negative value")
+			}
+
+			return nil
+		}),
+	).
+		Set(0, 0).
+		Set(1, 1)
+
+	_, err := sm.ApplyBatch([]sortedmap.Op[int, int]{
+		{Kind: sortedmap.OpSet, Key: 2, Value: 2},
+		{Kind: sortedmap.OpSet, Key: 3, Value: -3},
+	})
+	require.Error(t, err)
+
+	assert.Equal(t, []int{0, 1}, sm.Keys())
+	assert.Empty(t, evicted)
+
+	_, err = sm.ApplyBatch([]sortedmap.Op[int, int]{
+		{Kind: sortedmap.OpSet, Key: 2, Value: 2},
+		{Kind: sortedmap.OpSet, Key: 3, Value: 3},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{2, 3}, sm.Keys())
+	assert.Equal(t, []int{0, 1}, evicted)
+
+	// the eviction callback is still called outside of batches
+	sm.Set(4, 4)
+	assert.Equal(t, []int{0, 1, 2}, evicted)
+}
+
+func TestSortedMap_ApplyBatch_RollbackVersion(t *testing.T) {
+	sm := sortedmap.New[string, int]().Set("key1", 1)
+
+	version := sm.Version()
+
+	_, err := sm.ApplyBatch([]sortedmap.Op[string, int]{
+		{Kind: sortedmap.OpSet, Key: "key1", Value: 10},
+		{Kind: sortedmap.OpSet, Key: "key2", Value: 2},
+		{Kind: sortedmap.OpDelete, Key: "key1"},
+		{Kind: 42},
+	})
+	require.ErrorIs(t, err, sortedmap.ErrUnknownOpKind)
+
+	assert.Equal(t, version, sm.Version())
+	assert.Equal(t, []string{"key1"}, sm.Keys())
+	assert.Equal(t, []int{1}, sm.Values())
+
+	_, err = sm.ApplyBatch([]sortedmap.Op[string, int]{
+		{Kind: sortedmap.OpSet, Key: "key1", Value: 10},
+		{Kind: sortedmap.OpSet, Key: "key2", Value: 2},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, version+2, sm.Version())
+}
+
+func TestSortedMap_ApplyBatch_RollbackPanic(t *testing.T) {
+	sm := sortedmap.New(sortedmap.WithValidator(func(key string, _ int) error {
+		if key == "panic" {
+			panic("validator panicked")
+		}
+
+		return nil
+	})).
+		Set("key1", 1)
+
+	assert.PanicsWithValue(t, "validator panicked", func() {
+		_, _ = sm.ApplyBatch([]sortedmap.Op[string, int]{
+			{Kind: sortedmap.OpDelete, Key: "key1"},
+			{Kind: sortedmap.OpSet, Key: "key2", Value: 2},
+			{Kind: sortedmap.OpSet, Key: "panic", Value: 3},
+		})
+	})
+
+	assert.Equal(t, []string{"key1"}, sm.Keys())
+	assert.Equal(t, []int{1}, sm.Values())
+}
@@ -0,0 +1,179 @@
+package sortedmap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+var (
+	ErrInvalidFormat      = errors.New("invalid format")
+	ErrUnsupportedVersion = errors.New("unsupported format version")
+)
+
+var binaryMagic = [4]byte{'S', 'M', 'A', 'P'}
+
+const binaryVersion byte = 1
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+
+	return n, err
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+
+	return n, err
+}
+
+func writeChunk(w io.Writer, data []byte) error {
+	if len(data) > math.MaxUint32 {
+		return fmt.Errorf("%w: chunk too large", ErrInvalidFormat)
+	}
+
+	if err := binary.Write(w, binary.BigEndian, uint32(len(data))); err != nil {
+		return err
+	}
+
+	_, err := w.Write(data)
+
+	return err
+}
+
+func readChunk(r io.Reader) ([]byte, error) {
+	var size uint32
+	if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+		return nil, err
+	}
+
+	// the size is not trusted, so memory only grows with the bytes actually read
+	var buf bytes.Buffer
+	if _, err := io.CopyN(&buf, r, int64(size)); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// WriteTo writes the map to w in a binary format: a 4 byte magic number, a 1 byte format version, a 4 byte entry
+// count, followed by the length-prefixed JSON encoding of each key and value.
+func (sm *SortedMap[K, T]) WriteTo(w io.Writer) (int64, error) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	cw := &countingWriter{w: w}
+
+	if len(sm.sortedKeys) > math.MaxUint32 {
+		return 0, fmt.Errorf("%w: too many entries", ErrInvalidFormat)
+	}
+
+	if _, err := cw.Write(binaryMagic[:]); err != nil {
+		return cw.n, err
+	}
+
+	if _, err := cw.Write([]byte{binaryVersion}); err != nil {
+		return cw.n, err
+	}
+
+	if err := binary.Write(cw, binary.BigEndian, uint32(len(sm.sortedKeys))); err != nil {
+		return cw.n, err
+	}
+
+	for _, key := range sm.sortedKeys {
+		keyData, err := json.Marshal(key)
+		if err != nil {
+			return cw.n, err
+		}
+
+		valueData, err := json.Marshal(sm.items[key])
+		if err != nil {
+			return cw.n, err
+		}
+
+		if err := writeChunk(cw, keyData); err != nil {
+			return cw.n, err
+		}
+
+		if err := writeChunk(cw, valueData); err != nil {
+			return cw.n, err
+		}
+	}
+
+	return cw.n, nil
+}
+
+// ReadFrom replaces the content of the map with the entries read from r in the format written by WriteTo. The map is
+// left unchanged if an error occurs.
+func (sm *SortedMap[K, T]) ReadFrom(r io.Reader) (int64, error) {
+	cr := &countingReader{r: r}
+
+	var header [5]byte
+	if _, err := io.ReadFull(cr, header[:]); err != nil {
+		return cr.n, err
+	}
+
+	if [4]byte(header[:4]) != binaryMagic {
+		return cr.n, ErrInvalidFormat
+	}
+
+	if header[4] != binaryVersion {
+		return cr.n, fmt.Errorf("%w: %d", ErrUnsupportedVersion, header[4])
+	}
+
+	var count uint32
+	if err := binary.Read(cr, binary.BigEndian, &count); err != nil {
+		return cr.n, err
+	}
+
+	entries := make([]Entry[K, T], 0, min(count, 1024))
+
+	for range count {
+		var entry Entry[K, T]
+
+		keyData, err := readChunk(cr)
+		if err != nil {
+			return cr.n, err
+		}
+
+		if err := json.Unmarshal(keyData, &entry.Key); err != nil {
+			return cr.n, err
+		}
+
+		valueData, err := readChunk(cr)
+		if err != nil {
+			return cr.n, err
+		}
+
+		if err := json.Unmarshal(valueData, &entry.Value); err != nil {
+			return cr.n, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	return cr.n, sm.replace(entries)
+}
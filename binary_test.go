@@ -0,0 +1,110 @@
+package sortedmap_test
+
+import (
+	"bytes"
+	"io"
+	"runtime"
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ io.WriterTo   = (*sortedmap.SortedMap[string, int])(nil)
+	_ io.ReaderFrom = (*sortedmap.SortedMap[string, int])(nil)
+)
+
+func TestSortedMap_WriteToReadFrom(t *testing.T) {
+	sm := sortedmap.New[float64, []string]().
+		Set(2.5, []string{"b", "c"}).
+		Set(-1, nil).
+		Set(1, []string{"a"})
+
+	var buf bytes.Buffer
+
+	written, err := sm.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), written)
+	assert.Equal(t, []byte("SMAP\x01\x00\x00\x00\x03"), buf.Bytes()[:9])
+
+	actual := sortedmap.New[float64, []string]().Set(100, nil)
+
+	read, err := actual.ReadFrom(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, written, read)
+
+	assert.Equal(t, sm.Keys(), actual.Keys())
+	assert.Equal(t, sm.Values(), actual.Values())
+}
+
+func TestSortedMap_WriteToReadFrom_Empty(t *testing.T) {
+	var buf bytes.Buffer
+
+	written, err := sortedmap.New[string, int]().WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), written)
+
+	actual := sortedmap.New[string, int]().Set("key1", 1)
+
+	_, err = actual.ReadFrom(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, 0, actual.Len())
+}
+
+func TestSortedMap_ReadFrom_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       []byte
+		expectedErr error
+	}{
+		{
+			name:        "invalid magic",
+			input:       []byte("SMAX\x01\x00\x00\x00\x00"),
+			expectedErr: sortedmap.ErrInvalidFormat,
+		},
+		{
+			name:        "unsupported version",
+			input:       []byte("SMAP\x02\x00\x00\x00\x00"),
+			expectedErr: sortedmap.ErrUnsupportedVersion,
+		},
+		{
+			name:        "truncated",
+			input:       []byte("SMAP\x01\x00\x00\x00\x01\x00\x00\x00\x03\"a"),
+			expectedErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name:        "huge length prefix",
+			input:       []byte("SMAP\x01\x00\x00\x00\x01\xff\xff\xff\xf0"),
+			expectedErr: io.ErrUnexpectedEOF,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sm := sortedmap.New[string, int]().Set("key1", 1)
+
+			_, err := sm.ReadFrom(bytes.NewReader(tt.input))
+			assert.ErrorIs(t, err, tt.expectedErr)
+
+			assert.Equal(t, []string{"key1"}, sm.Keys())
+		})
+	}
+}
+
+func TestSortedMap_ReadFrom_HugeLengthPrefix(t *testing.T) {
+	input := []byte("SMAP\x01\x00\x00\x00\x01\xff\xff\xff\xf0")
+
+	var before, after runtime.MemStats
+
+	runtime.ReadMemStats(&before)
+
+	_, err := sortedmap.New[string, int]().ReadFrom(bytes.NewReader(input))
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	runtime.ReadMemStats(&after)
+
+	// the length prefix claims 4 GB, but only the bytes present may be allocated
+	assert.Less(t, after.TotalAlloc-before.TotalAlloc, uint64(1<<20))
+}
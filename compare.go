@@ -0,0 +1,131 @@
+package sortedmap
+
+import (
+	"cmp"
+	"reflect"
+
+	"golang.org/x/exp/constraints"
+)
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (sm *SortedMap[K, T]) compare(other *SortedMap[K, T], compareValues func(T, T) int) int {
+	otherKeys := other.keysIn(sm.direction)
+
+	for i := 0; i < len(sm.sortedKeys) && i < len(otherKeys); i++ {
+		key, otherKey := sm.sortedKeys[i], otherKeys[i]
+
+		if c := compareKeys(key, otherKey, sm.direction); c != 0 {
+			return c
+		}
+
+		if compareValues == nil {
+			continue
+		}
+
+		if c := sign(compareValues(sm.items[key], other.items[otherKey])); c != 0 {
+			return c
+		}
+	}
+
+	return cmp.Compare(len(sm.sortedKeys), len(other.sortedKeys))
+}
+
+// Compare compares two maps lexicographically by their sorted entries and returns -1, 0 or 1. Keys are compared first,
+// values of equal keys are compared using compareValues. If compareValues is nil, values are ignored.
+func (sm *SortedMap[K, T]) Compare(other *SortedMap[K, T], compareValues func(T, T) int) int {
+	unlock := lockPair(sm, false, other, false)
+	defer unlock()
+
+	return sm.compare(other, compareValues)
+}
+
+// CompareKeys compares the sorted keys of two maps lexicographically and returns -1, 0 or 1.
+func (sm *SortedMap[K, T]) CompareKeys(other *SortedMap[K, T]) int {
+	return sm.Compare(other, nil)
+}
+
+// CompareKeysAndValues compares two maps lexicographically by their sorted entries and returns -1, 0 or 1.
+func CompareKeysAndValues[K constraints.Ordered, T constraints.Ordered](a, b *SortedMap[K, T]) int {
+	return a.Compare(b, cmp.Compare[T])
+}
+
+// EqualKeys reports whether two maps hold the same keys, the values are ignored.
+func (sm *SortedMap[K, T]) EqualKeys(other *SortedMap[K, T]) bool {
+	unlock := lockPair(sm, false, other, false)
+	defer unlock()
+
+	if len(sm.sortedKeys) != len(other.sortedKeys) {
+		return false
+	}
+
+	otherKeys := other.keysIn(sm.direction)
+
+	for i, key := range sm.sortedKeys {
+		if key != otherKeys[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// EqualValues reports whether the values of two maps are equal in sorted key order, the keys themselves are ignored.
+func EqualValues[K constraints.Ordered, T comparable](a, b *SortedMap[K, T]) bool {
+	unlock := lockPair(a, false, b, false)
+	defer unlock()
+
+	if len(a.sortedKeys) != len(b.sortedKeys) {
+		return false
+	}
+
+	bKeys := b.keysIn(a.direction)
+
+	for i, key := range a.sortedKeys {
+		if a.items[key] != b.items[bKeys[i]] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// EqualFunc reports whether two maps hold the same keys with values equal according to eq.
+func EqualFunc[K constraints.Ordered, T any](a, b *SortedMap[K, T], eq func(T, T) bool) bool {
+	unlock := lockPair(a, false, b, false)
+	defer unlock()
+
+	if len(a.sortedKeys) != len(b.sortedKeys) {
+		return false
+	}
+
+	for _, key := range a.sortedKeys {
+		value, exists := b.items[key]
+		if !exists || !eq(a.items[key], value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equal reports whether two maps hold the same keys with values equal according to eq. If eq is nil, values are
+// compared using reflect.DeepEqual.
+func (sm *SortedMap[K, T]) Equal(other *SortedMap[K, T], eq func(T, T) bool) bool {
+	if eq == nil {
+		eq = func(a, b T) bool {
+			return reflect.DeepEqual(a, b)
+		}
+	}
+
+	return EqualFunc(sm, other, eq)
+}
@@ -0,0 +1,133 @@
+package sortedmap_test
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortedMap_Compare(t *testing.T) {
+	tests := []struct {
+		name                string
+		a, b                *sortedmap.SortedMap[string, int]
+		expectedKeys        int
+		expectedKeysValues  int
+		expectedCustomOrder int
+	}{
+		{
+			name:                "equal",
+			a:                   sortedmap.New[string, int]().Set("a", 1).Set("b", 2),
+			b:                   sortedmap.New[string, int]().Set("b", 2).Set("a", 1),
+			expectedKeys:        0,
+			expectedKeysValues:  0,
+			expectedCustomOrder: 0,
+		},
+		{
+			name:                "smaller key",
+			a:                   sortedmap.New[string, int]().Set("a", 1).Set("b", 2),
+			b:                   sortedmap.New[string, int]().Set("a", 1).Set("c", 0),
+			expectedKeys:        -1,
+			expectedKeysValues:  -1,
+			expectedCustomOrder: -1,
+		},
+		{
+			name:                "prefix",
+			a:                   sortedmap.New[string, int]().Set("a", 1).Set("b", 2),
+			b:                   sortedmap.New[string, int]().Set("a", 1),
+			expectedKeys:        1,
+			expectedKeysValues:  1,
+			expectedCustomOrder: 1,
+		},
+		{
+			name:                "same keys, different values",
+			a:                   sortedmap.New[string, int]().Set("a", 1).Set("b", 2),
+			b:                   sortedmap.New[string, int]().Set("a", 1).Set("b", 3),
+			expectedKeys:        0,
+			expectedKeysValues:  -1,
+			expectedCustomOrder: 1,
+		},
+		{
+			name:                "both empty",
+			a:                   sortedmap.New[string, int](),
+			b:                   sortedmap.New[string, int](),
+			expectedKeys:        0,
+			expectedKeysValues:  0,
+			expectedCustomOrder: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedKeys, tt.a.CompareKeys(tt.b))
+			assert.Equal(t, tt.expectedKeysValues, sortedmap.CompareKeysAndValues(tt.a, tt.b))
+			assert.Equal(t, tt.expectedCustomOrder, tt.a.Compare(tt.b, func(x, y int) int {
+				return cmp.Compare(y, x) * 100
+			}))
+		})
+	}
+}
+
+func TestSortedMap_EqualKeys(t *testing.T) {
+	a := sortedmap.New[string, int]().Set("a", 1).Set("b", 2)
+
+	assert.True(t, a.EqualKeys(sortedmap.New[string, int]().Set("b", 20).Set("a", 10)))
+	assert.True(t, a.EqualKeys(a))
+	assert.False(t, a.EqualKeys(sortedmap.New[string, int]().Set("a", 1).Set("c", 2)))
+	assert.False(t, a.EqualKeys(sortedmap.New[string, int]().Set("a", 1)))
+}
+
+func TestEqualValues(t *testing.T) {
+	a := sortedmap.New[string, int]().Set("a", 1).Set("b", 2)
+
+	assert.True(t, sortedmap.EqualValues(a, sortedmap.New[string, int]().Set("x", 1).Set("y", 2)))
+	assert.False(t, sortedmap.EqualValues(a, sortedmap.New[string, int]().Set("x", 2).Set("y", 1)))
+	assert.False(t, sortedmap.EqualValues(a, sortedmap.New[string, int]().Set("a", 1)))
+}
+
+func TestEqualFunc(t *testing.T) {
+	a := sortedmap.New[string, []string]().
+		Set("Accept", []string{"text/html"}).
+		Set("Vary", nil)
+
+	b := sortedmap.New(sortedmap.WithDescending[string, []string]()).
+		Set("Accept", []string{"text/html"}).
+		Set("Vary", []string{})
+
+	assert.True(t, sortedmap.EqualFunc(a, b, slices.Equal[[]string]))
+	assert.False(t, sortedmap.EqualFunc(a, b, func(x, y []string) bool {
+		return reflect.DeepEqual(x, y)
+	}))
+
+	b.Set("Accept", []string{"text/plain"})
+
+	assert.False(t, sortedmap.EqualFunc(a, b, slices.Equal[[]string]))
+
+	b.Delete("Accept").Set("Other", nil)
+
+	assert.False(t, sortedmap.EqualFunc(a, b, slices.Equal[[]string]))
+	assert.False(t, sortedmap.EqualFunc(a, sortedmap.New[string, []string](), slices.Equal[[]string]))
+}
+
+func TestSortedMap_Equal(t *testing.T) {
+	a := sortedmap.New[string, []int]().Set("key1", []int{1}).Set("key2", []int{2, 3})
+	b := sortedmap.New[string, []int]().Set("key2", []int{2, 3}).Set("key1", []int{1})
+
+	assert.True(t, a.Equal(b, nil))
+	assert.True(t, a.Equal(a, nil))
+	assert.True(t, a.Equal(b, func(x, y []int) bool {
+		return len(x) == len(y)
+	}))
+
+	b.Set("key1", []int{10})
+	assert.False(t, a.Equal(b, nil))
+	assert.True(t, a.Equal(b, func(x, y []int) bool {
+		return len(x) == len(y)
+	}))
+
+	assert.False(t, a.Equal(sortedmap.New[string, []int]().Set("key1", []int{1}), nil))
+	assert.False(t, a.Equal(sortedmap.New[string, []int]().Set("key1", []int{1}).Set("key3", []int{2, 3}), nil))
+}
@@ -0,0 +1,77 @@
+package sortedmap
+
+// evictN removes the n smallest or largest keys of the map according to policy, calling onEvict for each of them.
+// EvictNone and unknown policies evict nothing, like evict does.
+func (sm *SortedMap[K, T]) evictN(n int, policy EvictionPolicy) {
+	if policy != EvictSmallest && policy != EvictLargest {
+		return
+	}
+
+	n = min(max(n, 0), len(sm.sortedKeys))
+	if n == 0 {
+		return
+	}
+
+	evicted := make([]K, n)
+
+	if (policy == EvictSmallest) == (sm.direction == ascending) {
+		copy(evicted, sm.sortedKeys[:n])
+		copy(sm.sortedKeys, sm.sortedKeys[n:])
+	} else {
+		copy(evicted, sm.sortedKeys[len(sm.sortedKeys)-n:])
+	}
+
+	clear(sm.sortedKeys[len(sm.sortedKeys)-n:])
+
+	sm.sortedKeys = sm.sortedKeys[:len(sm.sortedKeys)-n]
+
+	for _, key := range evicted {
+		value := sm.items[key]
+
+		delete(sm.items, key)
+
+		sm.version.Add(1)
+
+		sm.notifyDelete(key, value)
+
+		if sm.onEvict != nil {
+			sm.onEvict(key, value)
+		}
+	}
+}
+
+// Shrink evicts the smallest keys of the map until it has at most maxSize entries.
+func (sm *SortedMap[K, T]) Shrink(maxSize int) *SortedMap[K, T] {
+	return sm.ShrinkWithPolicy(maxSize, EvictSmallest)
+}
+
+// ShrinkWithPolicy works like Shrink, but evicts entries according to policy. EvictNone and unknown policies leave the
+// map unchanged.
+func (sm *SortedMap[K, T]) ShrinkWithPolicy(maxSize int, policy EvictionPolicy) *SortedMap[K, T] {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	sm.evictN(len(sm.sortedKeys)-max(maxSize, 0), policy)
+
+	return sm
+}
+
+// Evict removes the n smallest keys of the map, passing them to the eviction callback of the map if there is any.
+func (sm *SortedMap[K, T]) Evict(n int) *SortedMap[K, T] {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	sm.evictN(n, EvictSmallest)
+
+	return sm
+}
+
+// EvictLargest works like Evict, but removes the n largest keys.
+func (sm *SortedMap[K, T]) EvictLargest(n int) *SortedMap[K, T] {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	sm.evictN(n, EvictLargest)
+
+	return sm
+}
@@ -0,0 +1,103 @@
+package sortedmap_test
+
+import (
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortedMap_Shrink(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            []sortedmap.Option[int, int]
+		policy          sortedmap.EvictionPolicy
+		maxSize         int
+		expectedKeys    []int
+		expectedEvicted []int
+	}{
+		{
+			name:            "smallest",
+			policy:          sortedmap.EvictSmallest,
+			maxSize:         2,
+			expectedKeys:    []int{4, 5},
+			expectedEvicted: []int{1, 2, 3},
+		},
+		{
+			name:            "largest",
+			policy:          sortedmap.EvictLargest,
+			maxSize:         3,
+			expectedKeys:    []int{1, 2, 3},
+			expectedEvicted: []int{4, 5},
+		},
+		{
+			name:            "smallest descending",
+			opts:            []sortedmap.Option[int, int]{sortedmap.WithDescending[int, int]()},
+			policy:          sortedmap.EvictSmallest,
+			maxSize:         3,
+			expectedKeys:    []int{5, 4, 3},
+			expectedEvicted: []int{2, 1},
+		},
+		{
+			name:            "no eviction",
+			policy:          sortedmap.EvictNone,
+			maxSize:         0,
+			expectedKeys:    []int{1, 2, 3, 4, 5},
+			expectedEvicted: nil,
+		},
+		{
+			name:            "unknown policy",
+			policy:          sortedmap.EvictionPolicy(42),
+			maxSize:         0,
+			expectedKeys:    []int{1, 2, 3, 4, 5},
+			expectedEvicted: nil,
+		},
+		{
+			name:            "already small enough",
+			policy:          sortedmap.EvictSmallest,
+			maxSize:         10,
+			expectedKeys:    []int{1, 2, 3, 4, 5},
+			expectedEvicted: nil,
+		},
+		{
+			name:            "negative size",
+			policy:          sortedmap.EvictSmallest,
+			maxSize:         -1,
+			expectedKeys:    []int{},
+			expectedEvicted: []int{1, 2, 3, 4, 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var evicted []int
+
+			opts := append(tt.opts, sortedmap.WithMaxSize(0, func(key int, _ int) {
+				evicted = append(evicted, key)
+			}))
+
+			sm := sortedmap.New(opts...)
+			for i := 1; i <= 5; i++ {
+				sm.Set(i, i)
+			}
+
+			sm.ShrinkWithPolicy(tt.maxSize, tt.policy)
+
+			assert.Equal(t, tt.expectedKeys, sm.Keys())
+			assert.Equal(t, tt.expectedKeys, sm.Values())
+			assert.Equal(t, tt.expectedEvicted, evicted)
+		})
+	}
+}
+
+func TestSortedMap_Evict(t *testing.T) {
+	sm := sortedmap.New[int, int]()
+	for i := 1; i <= 5; i++ {
+		sm.Set(i, i)
+	}
+
+	assert.Equal(t, []int{2, 3, 4, 5}, sm.Evict(1).Keys())
+	assert.Equal(t, []int{2, 3}, sm.EvictLargest(2).Keys())
+	assert.Equal(t, []int{3}, sm.Shrink(1).Keys())
+	assert.Equal(t, []int{}, sm.Evict(5).Keys())
+}
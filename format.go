@@ -0,0 +1,101 @@
+package sortedmap
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// GoString returns a Go expression which rebuilds the map, values are formatted using %#v.
+func (sm *SortedMap[K, T]) GoString() string {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "sortedmap.New[%s, %s]()", reflect.TypeFor[K](), reflect.TypeFor[T]())
+
+	for _, key := range sm.sortedKeys {
+		fmt.Fprintf(&b, ".Set(%#v, %#v)", key, sm.items[key])
+	}
+
+	return b.String()
+}
+
+// formatValue formats v for humans: using String or GoString if v implements them, floats without exponent, and %v
+// for everything else.
+func formatValue(v any) string {
+	switch v.(type) {
+	case fmt.Stringer:
+		return fmt.Sprintf("%v", v)
+	case fmt.GoStringer:
+		return fmt.Sprintf("%#v", v)
+	}
+
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Float32 || rv.Kind() == reflect.Float64 {
+		return strconv.FormatFloat(rv.Float(), 'f', -1, rv.Type().Bits())
+	}
+
+	return fmt.Sprintf("%v", v)
+}
+
+// String returns the entries of the map on a single line, in sorted order.
+func (sm *SortedMap[K, T]) String() string {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	var b strings.Builder
+
+	b.WriteString("{")
+
+	for i, key := range sm.sortedKeys {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+
+		fmt.Fprintf(&b, "%s: %s", formatValue(key), formatValue(sm.items[key]))
+	}
+
+	b.WriteString("}")
+
+	return b.String()
+}
+
+// Dump returns the entries of the map one per line, in sorted order.
+func (sm *SortedMap[K, T]) Dump() string {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	var b strings.Builder
+
+	for _, key := range sm.sortedKeys {
+		fmt.Fprintf(&b, "%s: %s\n", formatValue(key), formatValue(sm.items[key]))
+	}
+
+	return b.String()
+}
+
+// logValueLimit is the maximum number of entries logged by LogValue.
+const logValueLimit = 100
+
+// LogValue implements slog.LogValuer, logging the entries as a group. Only the first 100 entries are logged, followed
+// by a "truncated" attribute holding the number of entries left out.
+func (sm *SortedMap[K, T]) LogValue() slog.Value {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	n := min(len(sm.sortedKeys), logValueLimit)
+
+	attrs := make([]slog.Attr, 0, n+1)
+	for _, key := range sm.sortedKeys[:n] {
+		attrs = append(attrs, slog.Any(formatValue(key), sm.items[key]))
+	}
+
+	if n < len(sm.sortedKeys) {
+		attrs = append(attrs, slog.Int("truncated", len(sm.sortedKeys)-n))
+	}
+
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,150 @@
+package sortedmap_test
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+)
+
+type goStringerValue struct {
+	n int
+}
+
+func (g goStringerValue) GoString() string {
+	return fmt.Sprintf("goStringerValue{n: %d}", g.n)
+}
+
+func TestSortedMap_GoString(t *testing.T) {
+	tests := []struct {
+		name     string
+		sm       fmt.GoStringer
+		expected string
+	}{
+		{
+			name:     "empty",
+			sm:       sortedmap.New[string, int](),
+			expected: `sortedmap.New[string, int]()`,
+		},
+		{
+			name:     "string keys, int values",
+			sm:       sortedmap.New[string, int]().Set("b", 2).Set("a", 1),
+			expected: `sortedmap.New[string, int]().Set("a", 1).Set("b", 2)`,
+		},
+		{
+			name:     "float keys, string values",
+			sm:       sortedmap.New[float64, string]().Set(2.5, "b").Set(1, "a"),
+			expected: `sortedmap.New[float64, string]().Set(1, "a").Set(2.5, "b")`,
+		},
+		{
+			name:     "go stringer values",
+			sm:       sortedmap.New[int, goStringerValue]().Set(1, goStringerValue{n: 3}),
+			expected: `sortedmap.New[int, sortedmap_test.goStringerValue]().Set(1, goStringerValue{n: 3})`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.sm.GoString())
+			assert.Equal(t, tt.expected, fmt.Sprintf("%#v", tt.sm))
+		})
+	}
+}
+
+func TestSortedMap_GoString_NonCompilingValues(t *testing.T) {
+	value := 1
+
+	sm := sortedmap.New[string, *int]().Set("a", &value)
+
+	assert.Regexp(t, `^sortedmap\.New\[string, \*int\]\(\)\.Set\("a", \(\*int\)\(0x[0-9a-f]+\)\)$`, sm.GoString())
+}
+
+type level int
+
+func (l level) String() string {
+	return [...]string{"debug", "info", "error"}[l]
+}
+
+type point struct {
+	x, y int
+}
+
+func TestSortedMap_String(t *testing.T) {
+	tests := []struct {
+		name         string
+		sm           fmt.Stringer
+		expected     string
+		expectedDump string
+	}{
+		{
+			name:         "empty",
+			sm:           sortedmap.New[string, int](),
+			expected:     `{}`,
+			expectedDump: ``,
+		},
+		{
+			name:         "string keys, int values",
+			sm:           sortedmap.New[string, int]().Set("b", 2).Set("a", 1),
+			expected:     `{a: 1, b: 2}`,
+			expectedDump: "a: 1\nb: 2\n",
+		},
+		{
+			name:         "stringer keys",
+			sm:           sortedmap.New[level, point]().Set(2, point{x: 1, y: 2}).Set(0, point{}),
+			expected:     `{debug: {0 0}, error: {1 2}}`,
+			expectedDump: "debug: {0 0}\nerror: {1 2}\n",
+		},
+		{
+			name:         "go stringer values",
+			sm:           sortedmap.New[int, goStringerValue]().Set(1, goStringerValue{n: 3}),
+			expected:     `{1: goStringerValue{n: 3}}`,
+			expectedDump: "1: goStringerValue{n: 3}\n",
+		},
+		{
+			name:         "floats without exponent",
+			sm:           sortedmap.New[float64, float32]().Set(1e21, 0.5).Set(2, 1e-7),
+			expected:     `{2: 0.0000001, 1000000000000000000000: 0.5}`,
+			expectedDump: "2: 0.0000001\n1000000000000000000000: 0.5\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.sm.String())
+			assert.Equal(t, tt.expected, fmt.Sprint(tt.sm))
+			assert.Equal(t, tt.expectedDump, tt.sm.(interface{ Dump() string }).Dump())
+		})
+	}
+}
+
+func TestSortedMap_LogValue(t *testing.T) {
+	var b strings.Builder
+
+	logger := slog.New(slog.NewTextHandler(&b, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+
+	logger.Info("result", "map", sortedmap.New[string, int]().Set("b", 2).Set("a", 1))
+
+	assert.Equal(t, "level=INFO msg=result map.a=1 map.b=2\n", b.String())
+
+	sm := sortedmap.New[int, int]()
+	for i := range 105 {
+		sm.Set(i, i)
+	}
+
+	attrs := sm.LogValue().Group()
+
+	assert.Len(t, attrs, 101)
+	assert.Equal(t, "99", attrs[99].Key)
+	assert.Equal(t, slog.Int("truncated", 5), attrs[100])
+}
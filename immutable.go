@@ -0,0 +1,115 @@
+package sortedmap
+
+import (
+	"errors"
+	"iter"
+
+	"golang.org/x/exp/constraints"
+)
+
+// ErrImmutable is the panic value of the methods of ImmutableSortedMap which would modify the map.
+var ErrImmutable = errors.New("ImmutableSortedMap cannot be modified")
+
+// ImmutableSortedMap is a read-only view of a SortedMap. It keeps the methods modifying the map so that it can replace
+// a SortedMap in read-only code, but they panic with ErrImmutable instead of changing anything.
+type ImmutableSortedMap[K constraints.Ordered, T any] struct {
+	sm *SortedMap[K, T]
+}
+
+// Immutable returns a read-only map sharing the data of sm. The data is not copied, so sm itself must not be modified
+// afterwards.
+func (sm *SortedMap[K, T]) Immutable() *ImmutableSortedMap[K, T] {
+	return &ImmutableSortedMap[K, T]{sm: sm}
+}
+
+// NewImmutable creates an immutable map from entries, the last value wins for duplicate keys.
+func NewImmutable[K constraints.Ordered, T any](entries []Entry[K, T]) *ImmutableSortedMap[K, T] {
+	sorted := make([]Entry[K, T], len(entries))
+	copy(sorted, entries)
+
+	return NewFromEntries(sorted).Immutable()
+}
+
+// Get works like SortedMap.Get.
+func (im *ImmutableSortedMap[K, T]) Get(key K) (T, error) {
+	return im.sm.Get(key)
+}
+
+// MustGet works like SortedMap.MustGet.
+func (im *ImmutableSortedMap[K, T]) MustGet(key K) T {
+	return im.sm.MustGet(key)
+}
+
+// Len returns the number of entries of the map.
+func (im *ImmutableSortedMap[K, T]) Len() int {
+	return im.sm.Len()
+}
+
+// Has reports whether key is present in the map.
+func (im *ImmutableSortedMap[K, T]) Has(key K) bool {
+	return im.sm.Has(key)
+}
+
+// HasAll reports whether all keys are present in the map.
+func (im *ImmutableSortedMap[K, T]) HasAll(keys ...K) bool {
+	return im.sm.HasAll(keys...)
+}
+
+// HasAny reports whether any of keys is present in the map.
+func (im *ImmutableSortedMap[K, T]) HasAny(keys ...K) bool {
+	return im.sm.HasAny(keys...)
+}
+
+// Keys returns a copy of the keys of the map in sorted order.
+func (im *ImmutableSortedMap[K, T]) Keys() []K {
+	return im.sm.Keys()
+}
+
+// Items returns an iterator over the entries of the map in sorted order.
+func (im *ImmutableSortedMap[K, T]) Items() iter.Seq2[K, T] {
+	return im.sm.Items()
+}
+
+// Values returns the values of the map in the order of their keys.
+func (im *ImmutableSortedMap[K, T]) Values() []T {
+	return im.sm.Values()
+}
+
+// First works like SortedMap.First.
+func (im *ImmutableSortedMap[K, T]) First() (K, T, error) {
+	return im.sm.First()
+}
+
+// Last works like SortedMap.Last.
+func (im *ImmutableSortedMap[K, T]) Last() (K, T, error) {
+	return im.sm.Last()
+}
+
+// ForEach works like SortedMap.ForEach.
+func (im *ImmutableSortedMap[K, T]) ForEach(f func(K, T)) *ImmutableSortedMap[K, T] {
+	im.sm.ForEach(f)
+
+	return im
+}
+
+// ForEachReverse works like SortedMap.ForEachReverse.
+func (im *ImmutableSortedMap[K, T]) ForEachReverse(f func(K, T)) *ImmutableSortedMap[K, T] {
+	im.sm.ForEachReverse(f)
+
+	return im
+}
+
+// Set always panics with ErrImmutable.
+func (im *ImmutableSortedMap[K, T]) Set(K, T) *ImmutableSortedMap[K, T] {
+	panic(ErrImmutable)
+}
+
+// Delete always panics with ErrImmutable.
+func (im *ImmutableSortedMap[K, T]) Delete(...K) *ImmutableSortedMap[K, T] {
+	panic(ErrImmutable)
+}
+
+// Clear always panics with ErrImmutable.
+func (im *ImmutableSortedMap[K, T]) Clear() *ImmutableSortedMap[K, T] {
+	panic(ErrImmutable)
+}
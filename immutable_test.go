@@ -0,0 +1,70 @@
+package sortedmap_test
+
+import (
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortedMap_Immutable(t *testing.T) {
+	key1, key2 := "key1", "key2"
+	value1, value2 := 1, 2
+
+	im := sortedmap.New[string, int]().
+		Set(key2, value2).
+		Set(key1, value1).
+		Immutable()
+
+	actualValue, err := im.Get(key1)
+	require.NoError(t, err)
+	assert.Equal(t, value1, actualValue)
+
+	assert.Equal(t, value2, im.MustGet(key2))
+	assert.Equal(t, 2, im.Len())
+	assert.True(t, im.Has(key1))
+	assert.True(t, im.HasAll(key1, key2))
+	assert.True(t, im.HasAny(key1, "nope"))
+	assert.Equal(t, []string{key1, key2}, im.Keys())
+	assert.Equal(t, []int{value1, value2}, im.Values())
+
+	assert.PanicsWithValue(t, sortedmap.ErrImmutable, func() {
+		im.Set(key1, value2)
+	})
+	assert.PanicsWithValue(t, sortedmap.ErrImmutable, func() {
+		im.Delete(key1)
+	})
+	assert.PanicsWithValue(t, sortedmap.ErrImmutable, func() {
+		im.Clear()
+	})
+
+	assert.Equal(t, []int{value1, value2}, im.Values())
+}
+
+func TestNewImmutable(t *testing.T) {
+	im := sortedmap.NewImmutable([]sortedmap.Entry[string, int]{
+		{Key: "key2", Value: 2},
+		{Key: "key1", Value: 1},
+		{Key: "key2", Value: -2},
+	})
+
+	assert.Equal(t, []string{"key1", "key2"}, im.Keys())
+	assert.Equal(t, []int{1, -2}, im.Values())
+
+	assert.Equal(t, 0, sortedmap.NewImmutable[string, int](nil).Len())
+}
+
+func TestImmutableSortedMap_ForEach(t *testing.T) {
+	im := sortedmap.New[int, int]().Set(2, 2).Set(1, 1).Immutable()
+
+	keys := make([]int, 0, 4)
+
+	im.ForEach(func(key, _ int) {
+		keys = append(keys, key)
+	}).ForEachReverse(func(key, _ int) {
+		keys = append(keys, key)
+	})
+
+	assert.Equal(t, []int{1, 2, 2, 1}, keys)
+}
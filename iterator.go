@@ -0,0 +1,205 @@
+package sortedmap
+
+import (
+	"slices"
+
+	"golang.org/x/exp/constraints"
+)
+
+// SeekIterator iterates over a snapshot of the entries of a map taken when it was created. It does not hold the lock
+// of the map, so the map can be modified during the iteration, but Close should be called to release the snapshot
+// once the iterator is no longer needed.
+type SeekIterator[K constraints.Ordered, T any] struct {
+	keys   []K
+	values []T
+	pos    int
+}
+
+// SeekIterator returns an iterator over a snapshot of the map, starting at startKey, or at the smallest key greater
+// than startKey if startKey is not present.
+func (sm *SortedMap[K, T]) SeekIterator(startKey K) *SeekIterator[K, T] {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	keys, values := sm.snapshot(searchSorted(sm.sortedKeys, startKey, sm.direction), len(sm.sortedKeys))
+
+	return &SeekIterator[K, T]{
+		keys:   keys,
+		values: values,
+		pos:    -1,
+	}
+}
+
+// IterateFrom is an alias of SeekIterator, the iteration includes key if it is present.
+func (sm *SortedMap[K, T]) IterateFrom(key K) *SeekIterator[K, T] {
+	return sm.SeekIterator(key)
+}
+
+// ForwardIterator returns an iterator over a snapshot of the map, starting at the 0-based index fromIndex.
+func (sm *SortedMap[K, T]) ForwardIterator(fromIndex int) *SeekIterator[K, T] {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	keys, values := sm.snapshot(min(max(fromIndex, 0), len(sm.sortedKeys)), len(sm.sortedKeys))
+
+	return &SeekIterator[K, T]{
+		keys:   keys,
+		values: values,
+		pos:    -1,
+	}
+}
+
+// BackwardIterator returns an iterator over a snapshot of the map in reverse order, starting at the 0-based index
+// fromIndex and moving toward index 0.
+func (sm *SortedMap[K, T]) BackwardIterator(fromIndex int) *SeekIterator[K, T] {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	keys, values := sm.snapshot(0, min(max(fromIndex+1, 0), len(sm.sortedKeys)))
+
+	slices.Reverse(keys)
+	slices.Reverse(values)
+
+	return &SeekIterator[K, T]{
+		keys:   keys,
+		values: values,
+		pos:    -1,
+	}
+}
+
+// Next advances the iterator to the next entry and reports whether there is one, it must be called before reading
+// the first entry.
+func (it *SeekIterator[K, T]) Next() bool {
+	if it.pos+1 >= len(it.keys) {
+		it.pos = len(it.keys)
+
+		return false
+	}
+
+	it.pos++
+
+	return true
+}
+
+func (it *SeekIterator[K, T]) valid() bool {
+	return it.pos >= 0 && it.pos < len(it.keys)
+}
+
+// Key returns the key of the current entry, or the zero value if the iterator is not positioned on an entry.
+func (it *SeekIterator[K, T]) Key() K {
+	if !it.valid() {
+		var zero K
+
+		return zero
+	}
+
+	return it.keys[it.pos]
+}
+
+// Value returns the value of the current entry, or the zero value if the iterator is not positioned on an entry.
+func (it *SeekIterator[K, T]) Value() T {
+	if !it.valid() {
+		var zero T
+
+		return zero
+	}
+
+	return it.values[it.pos]
+}
+
+// Close releases the snapshot of the iterator, Next returns false afterwards. It always returns nil.
+func (it *SeekIterator[K, T]) Close() error {
+	it.keys = nil
+	it.values = nil
+	it.pos = 0
+
+	return nil
+}
+
+// Cursor reads the live content of a map one entry at a time, only locking the map during each step. Entries set or
+// deleted concurrently may or may not be seen by the cursor.
+type Cursor[K constraints.Ordered, T any] struct {
+	sm        *SortedMap[K, T]
+	from      K
+	inclusive bool
+	atStart   bool
+}
+
+// NewCursor returns a cursor positioned before the first entry of the map.
+func (sm *SortedMap[K, T]) NewCursor() *Cursor[K, T] {
+	return &Cursor[K, T]{
+		sm:      sm,
+		atStart: true,
+	}
+}
+
+// Next returns the entry following the current position of the cursor and advances the cursor past it.
+func (c *Cursor[K, T]) Next() (K, T, bool) {
+	c.sm.mu.RLock()
+	defer c.sm.mu.RUnlock()
+
+	i := 0
+	if !c.atStart {
+		i = searchSorted(c.sm.sortedKeys, c.from, c.sm.direction)
+		if !c.inclusive && i < len(c.sm.sortedKeys) && c.sm.sortedKeys[i] == c.from {
+			i++
+		}
+	}
+
+	if i >= len(c.sm.sortedKeys) {
+		var (
+			zeroK K
+			zeroT T
+		)
+
+		return zeroK, zeroT, false
+	}
+
+	key := c.sm.sortedKeys[i]
+
+	c.from, c.inclusive, c.atStart = key, false, false
+
+	return key, c.sm.items[key], true
+}
+
+// Seek positions the cursor so that the next entry returned is the one with the smallest key >= key.
+func (c *Cursor[K, T]) Seek(key K) {
+	c.from, c.inclusive, c.atStart = key, true, false
+}
+
+// Rewind positions the cursor before the first entry of the map.
+func (c *Cursor[K, T]) Rewind() {
+	var zero K
+
+	c.from, c.inclusive, c.atStart = zero, false, true
+}
+
+// TeeIterator is one of the independent iterators returned by Tee.
+type TeeIterator[K constraints.Ordered, T any] struct {
+	SeekIterator[K, T]
+}
+
+// Tee returns n iterators over the same snapshot of the map, each of them advancing independently. The snapshot is
+// shared, so creating more iterators does not copy the entries again.
+func (sm *SortedMap[K, T]) Tee(n int) []*TeeIterator[K, T] {
+	if n <= 0 {
+		return []*TeeIterator[K, T]{}
+	}
+
+	sm.mu.RLock()
+	keys, values := sm.snapshot(0, len(sm.sortedKeys))
+	sm.mu.RUnlock()
+
+	iterators := make([]*TeeIterator[K, T], 0, n)
+	for range n {
+		iterators = append(iterators, &TeeIterator[K, T]{
+			SeekIterator: SeekIterator[K, T]{
+				keys:   keys,
+				values: values,
+				pos:    -1,
+			},
+		})
+	}
+
+	return iterators
+}
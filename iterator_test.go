@@ -0,0 +1,252 @@
+package sortedmap_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortedMap_SeekIterator(t *testing.T) {
+	key1, key2, key3 := "key1", "key2", "key3"
+	value1, value2, value3 := 1, 2, 3
+
+	sm := sortedmap.New[string, int]().
+		Set(key3, value3).
+		Set(key1, value1).
+		Set(key2, value2)
+
+	tests := []struct {
+		name           string
+		startKey       string
+		expectedKeys   []string
+		expectedValues []int
+	}{
+		{
+			name:           "existing key",
+			startKey:       key2,
+			expectedKeys:   []string{key2, key3},
+			expectedValues: []int{value2, value3},
+		},
+		{
+			name:           "missing key starts at ceiling",
+			startKey:       "key1a",
+			expectedKeys:   []string{key2, key3},
+			expectedValues: []int{value2, value3},
+		},
+		{
+			name:           "before first key",
+			startKey:       "a",
+			expectedKeys:   []string{key1, key2, key3},
+			expectedValues: []int{value1, value2, value3},
+		},
+		{
+			name:           "after last key",
+			startKey:       "z",
+			expectedKeys:   []string{},
+			expectedValues: []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			it := sm.SeekIterator(tt.startKey)
+
+			actualKeys := make([]string, 0, 3)
+			actualValues := make([]int, 0, 3)
+			for it.Next() {
+				actualKeys = append(actualKeys, it.Key())
+				actualValues = append(actualValues, it.Value())
+			}
+
+			require.NoError(t, it.Close())
+
+			assert.Equal(t, tt.expectedKeys, actualKeys)
+			assert.Equal(t, tt.expectedValues, actualValues)
+		})
+	}
+}
+
+func TestSortedMap_SeekIterator_Snapshot(t *testing.T) {
+	key1, key2, key3 := "key1", "key2", "key3"
+	value1, value2, value3 := 1, 2, 3
+
+	sm := sortedmap.New[string, int]().
+		Set(key1, value1).
+		Set(key2, value2)
+
+	var it io.Closer = sm.SeekIterator(key1)
+
+	sm.Set(key3, value3).Delete(key1)
+
+	seekIterator := it.(*sortedmap.SeekIterator[string, int])
+
+	require.True(t, seekIterator.Next())
+	assert.Equal(t, key1, seekIterator.Key())
+	assert.Equal(t, value1, seekIterator.Value())
+
+	require.True(t, seekIterator.Next())
+	assert.Equal(t, key2, seekIterator.Key())
+
+	assert.False(t, seekIterator.Next())
+	assert.Equal(t, "", seekIterator.Key())
+	assert.Equal(t, 0, seekIterator.Value())
+
+	require.NoError(t, it.Close())
+	assert.False(t, seekIterator.Next())
+}
+
+func TestSortedMap_IterateFrom(t *testing.T) {
+	sm := sortedmap.New[int, string]().
+		Set(1, "one").
+		Set(3, "three").
+		Set(5, "five")
+
+	it := sm.IterateFrom(3)
+
+	require.True(t, it.Next())
+	assert.Equal(t, 3, it.Key())
+	assert.Equal(t, "three", it.Value())
+
+	it = sm.IterateFrom(4)
+
+	require.True(t, it.Next())
+	assert.Equal(t, 5, it.Key())
+	assert.False(t, it.Next())
+}
+
+func TestSortedMap_ForwardIterator(t *testing.T) {
+	sm := sortedmap.New[int, string]().
+		Set(3, "three").
+		Set(1, "one").
+		Set(2, "two")
+
+	tests := []struct {
+		name         string
+		fromIndex    int
+		backward     bool
+		expectedKeys []int
+	}{
+		{name: "forward from start", fromIndex: 0, expectedKeys: []int{1, 2, 3}},
+		{name: "forward from last", fromIndex: 2, expectedKeys: []int{3}},
+		{name: "forward before start", fromIndex: -1, expectedKeys: []int{1, 2, 3}},
+		{name: "forward after end", fromIndex: 3, expectedKeys: []int{}},
+		{name: "backward from end", fromIndex: 2, backward: true, expectedKeys: []int{3, 2, 1}},
+		{name: "backward from middle", fromIndex: 1, backward: true, expectedKeys: []int{2, 1}},
+		{name: "backward after end", fromIndex: 10, backward: true, expectedKeys: []int{3, 2, 1}},
+		{name: "backward before start", fromIndex: -1, backward: true, expectedKeys: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			it := sm.ForwardIterator(tt.fromIndex)
+			if tt.backward {
+				it = sm.BackwardIterator(tt.fromIndex)
+			}
+
+			actualKeys := make([]int, 0, 3)
+			for it.Next() {
+				actualKeys = append(actualKeys, it.Key())
+			}
+
+			assert.Equal(t, tt.expectedKeys, actualKeys)
+		})
+	}
+}
+
+func TestCursor(t *testing.T) {
+	key1, key2, key3 := "key1", "key2", "key3"
+	value1, value2, value3 := 1, 2, 3
+
+	sm := sortedmap.New[string, int]().
+		Set(key3, value3).
+		Set(key1, value1)
+
+	c := sm.NewCursor()
+
+	key, value, ok := c.Next()
+	require.True(t, ok)
+	assert.Equal(t, key1, key)
+	assert.Equal(t, value1, value)
+
+	// the cursor sees the live content of the map
+	sm.Set(key2, value2)
+
+	key, value, ok = c.Next()
+	require.True(t, ok)
+	assert.Equal(t, key2, key)
+	assert.Equal(t, value2, value)
+
+	key, _, ok = c.Next()
+	require.True(t, ok)
+	assert.Equal(t, key3, key)
+
+	_, _, ok = c.Next()
+	assert.False(t, ok)
+
+	c.Seek("key15")
+
+	key, _, ok = c.Next()
+	require.True(t, ok)
+	assert.Equal(t, key2, key)
+
+	c.Seek(key1)
+
+	key, _, ok = c.Next()
+	require.True(t, ok)
+	assert.Equal(t, key1, key)
+
+	c.Rewind()
+
+	key, _, ok = c.Next()
+	require.True(t, ok)
+	assert.Equal(t, key1, key)
+}
+
+func TestCursor_DeletedPosition(t *testing.T) {
+	sm := sortedmap.New[int, int]().
+		Set(1, 1).
+		Set(2, 2).
+		Set(3, 3)
+
+	c := sm.NewCursor()
+
+	key, _, ok := c.Next()
+	require.True(t, ok)
+	assert.Equal(t, 1, key)
+
+	sm.Delete(1, 2)
+
+	key, _, ok = c.Next()
+	require.True(t, ok)
+	assert.Equal(t, 3, key)
+}
+
+func TestSortedMap_Tee(t *testing.T) {
+	sm := sortedmap.New[string, int]().
+		Set("key2", 2).
+		Set("key1", 1)
+
+	iterators := sm.Tee(2)
+	require.Len(t, iterators, 2)
+
+	sm.Set("key3", 3)
+
+	first, second := iterators[0], iterators[1]
+
+	require.True(t, first.Next())
+	require.True(t, first.Next())
+	assert.Equal(t, "key2", first.Key())
+	assert.Equal(t, 2, first.Value())
+	require.NoError(t, first.Close())
+
+	require.True(t, second.Next())
+	assert.Equal(t, "key1", second.Key())
+	require.True(t, second.Next())
+	assert.Equal(t, "key2", second.Key())
+	assert.False(t, second.Next())
+
+	assert.Empty(t, sm.Tee(0))
+}
@@ -0,0 +1,62 @@
+package sortedmap
+
+// LeftJoin returns a map with all the keys of sm. Values are combined using merge(smValue, otherValue), defaultVal is
+// used in place of otherValue for keys missing from other. If merge is nil, the values of sm are kept.
+func (sm *SortedMap[K, T]) LeftJoin(other *SortedMap[K, T], defaultVal T, merge func(T, T) T) *SortedMap[K, T] {
+	unlock := lockPair(sm, false, other, false)
+	defer unlock()
+
+	result := sm.newLike(len(sm.sortedKeys))
+
+	for _, key := range sm.sortedKeys {
+		value := sm.items[key]
+
+		if merge != nil {
+			otherValue, exists := other.items[key]
+			if !exists {
+				otherValue = defaultVal
+			}
+
+			value = merge(value, otherValue)
+		}
+
+		result.push(key, value)
+	}
+
+	return result
+}
+
+// RightJoin returns a map with all the keys of other. Values are combined using merge(smValue, otherValue), defaultVal
+// is used in place of smValue for keys missing from sm. If merge is nil, the values of other are kept.
+func (sm *SortedMap[K, T]) RightJoin(other *SortedMap[K, T], defaultVal T, merge func(T, T) T) *SortedMap[K, T] {
+	unlock := lockPair(sm, false, other, false)
+	defer unlock()
+
+	result := sm.newLike(len(other.sortedKeys))
+
+	for _, key := range other.keysIn(sm.direction) {
+		value := other.items[key]
+
+		if merge != nil {
+			smValue, exists := sm.items[key]
+			if !exists {
+				smValue = defaultVal
+			}
+
+			value = merge(smValue, value)
+		}
+
+		result.push(key, value)
+	}
+
+	return result
+}
+
+// InnerJoin returns a map with the keys present in both sm and other. Values are combined using
+// merge(smValue, otherValue), if merge is nil, the values of sm are kept.
+func (sm *SortedMap[K, T]) InnerJoin(other *SortedMap[K, T], merge func(T, T) T) *SortedMap[K, T] {
+	unlock := lockPair(sm, false, other, false)
+	defer unlock()
+
+	return sm.intersectWith(other, merge)
+}
@@ -0,0 +1,79 @@
+package sortedmap_test
+
+import (
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+)
+
+func sum(a, b int) int {
+	return a + b
+}
+
+func TestSortedMap_LeftJoin(t *testing.T) {
+	left := sortedmap.New[string, int]().
+		Set("key1", 1).
+		Set("key2", 2).
+		Set("key3", 3)
+
+	right := sortedmap.New[string, int]().
+		Set("key2", 20).
+		Set("key4", 40)
+
+	actual := left.LeftJoin(right, 100, sum)
+	assert.Equal(t, []string{"key1", "key2", "key3"}, actual.Keys())
+	assert.Equal(t, []int{101, 22, 103}, actual.Values())
+
+	actual = left.LeftJoin(right, 100, nil)
+	assert.Equal(t, []string{"key1", "key2", "key3"}, actual.Keys())
+	assert.Equal(t, []int{1, 2, 3}, actual.Values())
+
+	assert.Equal(t, 3, left.Len())
+	assert.Equal(t, 2, right.Len())
+}
+
+func TestSortedMap_RightJoin(t *testing.T) {
+	left := sortedmap.New[string, int]().
+		Set("key1", 1).
+		Set("key2", 2)
+
+	right := sortedmap.New[string, int]().
+		Set("key2", 20).
+		Set("key4", 40)
+
+	actual := left.RightJoin(right, 100, sum)
+	assert.Equal(t, []string{"key2", "key4"}, actual.Keys())
+	assert.Equal(t, []int{22, 140}, actual.Values())
+
+	actual = left.RightJoin(right, 100, nil)
+	assert.Equal(t, []int{20, 40}, actual.Values())
+}
+
+func TestSortedMap_InnerJoin(t *testing.T) {
+	left := sortedmap.New[string, int]().
+		Set("key1", 1).
+		Set("key2", 2).
+		Set("key3", 3)
+
+	right := sortedmap.New[string, int]().
+		Set("key3", 30).
+		Set("key2", 20).
+		Set("key4", 40)
+
+	actual := left.InnerJoin(right, sum)
+	assert.Equal(t, []string{"key2", "key3"}, actual.Keys())
+	assert.Equal(t, []int{22, 33}, actual.Values())
+
+	actual = left.InnerJoin(right, nil)
+	assert.Equal(t, []int{2, 3}, actual.Values())
+}
+
+func TestSortedMap_LeftJoinSelf(t *testing.T) {
+	sm := sortedmap.New[string, int]().
+		Set("key1", 1).
+		Set("key2", 2)
+
+	actual := sm.LeftJoin(sm, 0, sum)
+	assert.Equal(t, []int{2, 4}, actual.Values())
+}
@@ -0,0 +1,326 @@
+package sortedmap
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+
+	"golang.org/x/exp/constraints"
+)
+
+var (
+	ErrInvalidJSON        = errors.New("invalid JSON")
+	ErrDuplicateKey       = errors.New("duplicate key")
+	ErrUnsupportedKeyType = errors.New("unsupported key type")
+)
+
+func keyFromString[K constraints.Ordered](s string) (K, error) {
+	var key K
+
+	v := reflect.ValueOf(&key).Elem()
+	if v.Kind() != reflect.String {
+		return key, fmt.Errorf("%w: %s", ErrUnsupportedKeyType, v.Type())
+	}
+
+	v.SetString(s)
+
+	return key, nil
+}
+
+// NewFromJSON reads a JSON object from r, it only works for maps with string based keys, use NewFromJSONFunc otherwise.
+func NewFromJSON[K constraints.Ordered, T any](r io.Reader) (*SortedMap[K, T], error) {
+	return NewFromJSONFunc[K, T](r, keyFromString[K])
+}
+
+// NewFromJSONFunc reads a JSON object from r, converting its keys using keyFromString. An error returned by
+// keyFromString is returned as is, and a key appearing twice in the object fails with ErrDuplicateKey.
+func NewFromJSONFunc[K constraints.Ordered, T any](r io.Reader, keyFromString func(string) (K, error)) (*SortedMap[K, T], error) {
+	sm := New[K, T]()
+
+	err := decodeJSONObject(json.NewDecoder(r), keyFromString, func(key K, value T) error {
+		sm.items[key] = value
+		sm.sortedKeys = append(sm.sortedKeys, key)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(sm.sortedKeys, func(i, j int) bool {
+		return sm.sortedKeys[i] < sm.sortedKeys[j]
+	})
+
+	return sm, nil
+}
+
+// decodeJSONObject reads a JSON object entry by entry and calls add for each of them.
+func decodeJSONObject[K constraints.Ordered, T any](dec *json.Decoder, keyFromString func(string) (K, error), add func(K, T) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("%w: expected object, got %v", ErrInvalidJSON, tok)
+	}
+
+	seen := make(map[K]struct{})
+
+	for dec.More() {
+		tok, err = dec.Token()
+		if err != nil {
+			return err
+		}
+
+		rawKey, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("%w: expected object key, got %v", ErrInvalidJSON, tok)
+		}
+
+		key, err := keyFromString(rawKey)
+		if err != nil {
+			return err
+		}
+
+		if _, exists := seen[key]; exists {
+			return fmt.Errorf("%w: %q", ErrDuplicateKey, rawKey)
+		}
+
+		seen[key] = struct{}{}
+
+		var value T
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+
+		if err := add(key, value); err != nil {
+			return err
+		}
+	}
+
+	_, err = dec.Token()
+
+	return err
+}
+
+// keyFromJSONString parses an object key written by ExportJSON, non-string keys are parsed as JSON numbers.
+func keyFromJSONString[K constraints.Ordered](s string) (K, error) {
+	if isStringKey[K]() {
+		return keyFromString[K](s)
+	}
+
+	var key K
+	if err := json.Unmarshal([]byte(s), &key); err != nil {
+		return key, fmt.Errorf("%w: invalid key %q", ErrInvalidJSON, s)
+	}
+
+	return key, nil
+}
+
+func expectJSONDelim(dec *json.Decoder, expected json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if delim, ok := tok.(json.Delim); !ok || delim != expected {
+		return fmt.Errorf("%w: expected %v, got %v", ErrInvalidJSON, expected, tok)
+	}
+
+	return nil
+}
+
+// decodeJSONArray reads a JSON array of [key, value] pairs entry by entry and calls add for each of them.
+func decodeJSONArray[K constraints.Ordered, T any](dec *json.Decoder, add func(K, T) error) error {
+	if err := expectJSONDelim(dec, '['); err != nil {
+		return err
+	}
+
+	seen := make(map[K]struct{})
+
+	for dec.More() {
+		if err := expectJSONDelim(dec, '['); err != nil {
+			return err
+		}
+
+		var key K
+		if err := dec.Decode(&key); err != nil {
+			return err
+		}
+
+		if _, exists := seen[key]; exists {
+			return fmt.Errorf("%w: %v", ErrDuplicateKey, key)
+		}
+
+		seen[key] = struct{}{}
+
+		var value T
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+
+		if err := expectJSONDelim(dec, ']'); err != nil {
+			return err
+		}
+
+		if err := add(key, value); err != nil {
+			return err
+		}
+	}
+
+	return expectJSONDelim(dec, ']')
+}
+
+func keyToString[K constraints.Ordered](key K) string {
+	v := reflect.ValueOf(key)
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 32)
+	default:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	}
+}
+
+// ExportJSON writes the map to w as a JSON object one entry at a time, keys are written in sorted order. Non-string
+// keys are formatted using strconv, so they can be read back using NewFromJSONFunc.
+func (sm *SortedMap[K, T]) ExportJSON(w io.Writer) error {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	return sm.writeJSONObject(w)
+}
+
+func (sm *SortedMap[K, T]) writeJSONObject(w io.Writer) error {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+
+	buf.WriteByte('{')
+
+	for i, key := range sm.sortedKeys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		if err := enc.Encode(keyToString(key)); err != nil {
+			return err
+		}
+
+		buf.Truncate(buf.Len() - 1)
+		buf.WriteByte(':')
+
+		if err := enc.Encode(sm.items[key]); err != nil {
+			return err
+		}
+
+		buf.Truncate(buf.Len() - 1)
+
+		if _, err := buf.WriteTo(w); err != nil {
+			return err
+		}
+	}
+
+	buf.WriteByte('}')
+
+	_, err := buf.WriteTo(w)
+
+	return err
+}
+
+func isStringKey[K constraints.Ordered]() bool {
+	return reflect.TypeFor[K]().Kind() == reflect.String
+}
+
+// MarshalJSON encodes the map as a JSON object with keys in sorted order, non-string keys are formatted using strconv.
+func (sm *SortedMap[K, T]) MarshalJSON() ([]byte, error) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	var buf bytes.Buffer
+
+	if err := sm.writeJSONObject(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON replaces the content of the map with the entries of data, which can be a JSON object as written by
+// MarshalJSON or a JSON array of [key, value] pairs. The map is left unchanged if an error occurs.
+func (sm *SortedMap[K, T]) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	entries := make([]Entry[K, T], 0)
+
+	add := func(key K, value T) error {
+		entries = append(entries, Entry[K, T]{Key: key, Value: value})
+
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	var err error
+
+	switch {
+	case bytes.HasPrefix(data, []byte("{")):
+		err = decodeJSONObject(dec, keyFromJSONString[K], add)
+	case bytes.HasPrefix(data, []byte("[")):
+		err = decodeJSONArray(dec, add)
+	default:
+		err = fmt.Errorf("%w: expected object or array", ErrInvalidJSON)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// encoding/json allocates a zero map for nil pointers
+	if sm.mu == nil {
+		sm.mu = &sync.RWMutex{}
+		sm.direction = ascending
+	}
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	return sm.replace(entries)
+}
+
+// ImportJSON reads a JSON object from r, as written by ExportJSON, and sets its entries in the map. The object is read
+// as a whole before the map is locked, and either all of its entries are set or none of them if any of them is invalid
+// or rejected.
+func (sm *SortedMap[K, T]) ImportJSON(r io.Reader) error {
+	entries := make([]Entry[K, T], 0)
+
+	err := decodeJSONObject(json.NewDecoder(r), keyFromJSONString[K], func(key K, value T) error {
+		entries = append(entries, Entry[K, T]{Key: key, Value: value})
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	return sm.setAll(entries)
+}
@@ -0,0 +1,325 @@
+package sortedmap_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFromJSON(t *testing.T) {
+	sm, err := sortedmap.NewFromJSON[string, int](strings.NewReader(`{"key3": 3, "key1": 1, "key2": 2}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"key1", "key2", "key3"}, sm.Keys())
+	assert.Equal(t, []int{1, 2, 3}, sm.Values())
+}
+
+func TestNewFromJSON_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expectedErr error
+	}{
+		{
+			name:  "malformed JSON",
+			input: `{"key1": 1,`,
+		},
+		{
+			name:        "not an object",
+			input:       `[1, 2]`,
+			expectedErr: sortedmap.ErrInvalidJSON,
+		},
+		{
+			name:        "duplicate keys",
+			input:       `{"key1": 1, "key1": 2}`,
+			expectedErr: sortedmap.ErrDuplicateKey,
+		},
+		{
+			name:  "type mismatch",
+			input: `{"key1": "one"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := sortedmap.NewFromJSON[string, int](strings.NewReader(tt.input))
+			require.Error(t, err)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestNewFromJSON_TypeMismatch(t *testing.T) {
+	_, err := sortedmap.NewFromJSON[string, int](strings.NewReader(`{"key1": "one"}`))
+
+	var typeErr *json.UnmarshalTypeError
+	assert.ErrorAs(t, err, &typeErr)
+}
+
+func TestNewFromJSON_UnsupportedKeyType(t *testing.T) {
+	_, err := sortedmap.NewFromJSON[int, int](strings.NewReader(`{"1": 1}`))
+
+	assert.ErrorIs(t, err, sortedmap.ErrUnsupportedKeyType)
+}
+
+func TestNewFromJSONFunc(t *testing.T) {
+	sm, err := sortedmap.NewFromJSONFunc[int, string](strings.NewReader(`{"10": "ten", "2": "two"}`), strconv.Atoi)
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{2, 10}, sm.Keys())
+	assert.Equal(t, []string{"two", "ten"}, sm.Values())
+
+	_, err = sortedmap.NewFromJSONFunc[int, string](strings.NewReader(`{"1": "one", "01": "one"}`), strconv.Atoi)
+	assert.ErrorIs(t, err, sortedmap.ErrDuplicateKey)
+}
+
+func TestSortedMap_ExportJSON(t *testing.T) {
+	sm := sortedmap.New[string, int]().
+		Set("key2", 2).
+		Set("key1", 1).
+		Set("key3", 3)
+
+	var buf bytes.Buffer
+
+	require.NoError(t, sm.ExportJSON(&buf))
+	assert.Equal(t, `{"key1":1,"key2":2,"key3":3}`, buf.String())
+
+	actual, err := sortedmap.NewFromJSON[string, int](&buf)
+	require.NoError(t, err)
+	assert.Equal(t, sm.Keys(), actual.Keys())
+	assert.Equal(t, sm.Values(), actual.Values())
+}
+
+func TestSortedMap_ExportJSON_Empty(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, sortedmap.New[string, int]().ExportJSON(&buf))
+	assert.Equal(t, `{}`, buf.String())
+}
+
+func TestSortedMap_ExportJSON_NonStringKeys(t *testing.T) {
+	sm := sortedmap.New[int, []string]().
+		Set(10, []string{"a"}).
+		Set(-2, nil)
+
+	var buf bytes.Buffer
+
+	require.NoError(t, sm.ExportJSON(&buf))
+	assert.Equal(t, `{"-2":null,"10":["a"]}`, buf.String())
+
+	actual, err := sortedmap.NewFromJSONFunc[int, []string](&buf, strconv.Atoi)
+	require.NoError(t, err)
+	assert.Equal(t, sm.Keys(), actual.Keys())
+	assert.Equal(t, sm.Values(), actual.Values())
+}
+
+func TestSortedMap_ImportJSON_NonStringKeys(t *testing.T) {
+	sm := sortedmap.New[int, int]().
+		Set(10, 100).
+		Set(-2, 4)
+
+	var buf bytes.Buffer
+
+	require.NoError(t, sm.ExportJSON(&buf))
+
+	actual := sortedmap.New[int, int]().Set(1, 1)
+	require.NoError(t, actual.ImportJSON(&buf))
+
+	assert.Equal(t, []int{-2, 1, 10}, actual.Keys())
+	assert.Equal(t, []int{4, 1, 100}, actual.Values())
+
+	err := actual.ImportJSON(strings.NewReader(`{"3": 3, "x": 4}`))
+	assert.ErrorIs(t, err, sortedmap.ErrInvalidJSON)
+	assert.Equal(t, []int{-2, 1, 10}, actual.Keys())
+}
+
+func TestSortedMap_ImportJSON(t *testing.T) {
+	sm := sortedmap.New[string, int]().
+		Set("key1", 1).
+		Set("key2", 2)
+
+	err := sm.ImportJSON(strings.NewReader(`{"key3": 3, "key2": 20}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"key1", "key2", "key3"}, sm.Keys())
+	assert.Equal(t, []int{1, 20, 3}, sm.Values())
+
+	err = sm.ImportJSON(strings.NewReader(`{"key4": 4, "key4": 5}`))
+	assert.ErrorIs(t, err, sortedmap.ErrDuplicateKey)
+	assert.Equal(t, []string{"key1", "key2", "key3"}, sm.Keys())
+}
+
+func TestSortedMap_ImportJSON_Rejected(t *testing.T) {
+	errNegative := errors.New("negative value")
+
+	sm := sortedmap.New(sortedmap.WithValidator(func(_ string, value int) error {
+		if value < 0 {
+			return errNegative
+		}
+
+		return nil
+	})).
+		Set("z", 26)
+
+	version := sm.Version()
+
+	err := sm.ImportJSON(strings.NewReader(`{"a": 1, "b": -1}`))
+	assert.ErrorIs(t, err, errNegative)
+
+	assert.Equal(t, []string{"z"}, sm.Keys())
+	assert.Equal(t, version, sm.Version())
+}
+
+func TestSortedMap_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		sm       json.Marshaler
+		expected string
+	}{
+		{
+			name:     "string keys",
+			sm:       sortedmap.New[string, int]().Set("b", 2).Set("a", 1),
+			expected: `{"a":1,"b":2}`,
+		},
+		{
+			name:     "int keys",
+			sm:       sortedmap.New[int, string]().Set(10, "ten").Set(2, "two"),
+			expected: `{"2":"two","10":"ten"}`,
+		},
+		{
+			name:     "float keys",
+			sm:       sortedmap.New[float64, float64]().Set(1.5, 2.5),
+			expected: `{"1.5":2.5}`,
+		},
+		{
+			name:     "empty",
+			sm:       sortedmap.New[int, int](),
+			expected: `{}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := json.Marshal(tt.sm)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, tt.expected, string(actual))
+			assert.Equal(t, tt.expected, string(actual))
+		})
+	}
+}
+
+func TestSortedMap_MarshalJSON_Nested(t *testing.T) {
+	type response struct {
+		Counts *sortedmap.SortedMap[string, int] `json:"counts"`
+	}
+
+	actual, err := json.Marshal(response{
+		Counts: sortedmap.New(sortedmap.WithDescending[string, int]()).Set("a", 1).Set("b", 2),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"counts":{"b":2,"a":1}}`, string(actual))
+}
+
+func TestSortedMap_UnmarshalJSON(t *testing.T) {
+	t.Run("round trip with string keys", func(t *testing.T) {
+		expected := sortedmap.New[string, int]().Set("b", 2).Set("a", 1)
+
+		data, err := json.Marshal(expected)
+		require.NoError(t, err)
+
+		actual := sortedmap.New[string, int]().Set("c", 3)
+		require.NoError(t, json.Unmarshal(data, actual))
+
+		assert.Equal(t, []string{"a", "b"}, actual.Keys())
+		assert.Equal(t, []int{1, 2}, actual.Values())
+	})
+
+	t.Run("round trip with int keys", func(t *testing.T) {
+		expected := sortedmap.New[int, string]().Set(10, "ten").Set(2, "two")
+
+		data, err := json.Marshal(expected)
+		require.NoError(t, err)
+
+		actual := sortedmap.New[int, string]()
+		require.NoError(t, json.Unmarshal(data, actual))
+
+		assert.Equal(t, []int{2, 10}, actual.Keys())
+		assert.Equal(t, []string{"two", "ten"}, actual.Values())
+	})
+
+	t.Run("bare formats", func(t *testing.T) {
+		sm := sortedmap.New[int, string]()
+
+		require.NoError(t, json.Unmarshal([]byte(`{"10":"ten","2":"two"}`), sm))
+		assert.Equal(t, []int{2, 10}, sm.Keys())
+
+		require.NoError(t, json.Unmarshal([]byte(`[[3,"three"],[1,"one"]]`), sm))
+		assert.Equal(t, []int{1, 3}, sm.Keys())
+	})
+
+	t.Run("nil pointer field", func(t *testing.T) {
+		var actual struct {
+			Counts *sortedmap.SortedMap[string, int] `json:"counts"`
+		}
+
+		require.NoError(t, json.Unmarshal([]byte(`{"counts":{"b":2,"a":1}}`), &actual))
+		require.NotNil(t, actual.Counts)
+
+		assert.Equal(t, []string{"a", "b", "c"}, actual.Counts.Set("c", 3).Keys())
+	})
+
+	t.Run("errors keep the map unchanged", func(t *testing.T) {
+		sm := sortedmap.New[int, string]().Set(1, "one")
+
+		err := json.Unmarshal([]byte(`[[1,"one"],[1,"uno"]]`), sm)
+		assert.ErrorIs(t, err, sortedmap.ErrDuplicateKey)
+
+		err = json.Unmarshal([]byte(`[1,2]`), sm)
+		assert.ErrorIs(t, err, sortedmap.ErrInvalidJSON)
+
+		err = json.Unmarshal([]byte(`{"x":"one"}`), sm)
+		assert.ErrorIs(t, err, sortedmap.ErrInvalidJSON)
+
+		err = json.Unmarshal([]byte(`"one"`), sm)
+		assert.ErrorIs(t, err, sortedmap.ErrInvalidJSON)
+
+		assert.Equal(t, []int{1}, sm.Keys())
+	})
+}
+
+func TestSortedMap_ImportJSON_SlowReader(t *testing.T) {
+	sm := sortedmap.New[string, int]().Set("key1", 1)
+
+	r, w := io.Pipe()
+	done := make(chan error)
+
+	go func() {
+		done <- sm.ImportJSON(r)
+	}()
+
+	_, err := w.Write([]byte(`{"key2": 2,`))
+	require.NoError(t, err)
+
+	// the map can be read while the import waits for more input
+	assert.Equal(t, 1, sm.Len())
+
+	_, err = w.Write([]byte(`"key3": 3}`))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	require.NoError(t, <-done)
+	assert.Equal(t, []string{"key1", "key2", "key3"}, sm.Keys())
+}
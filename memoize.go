@@ -0,0 +1,133 @@
+package sortedmap
+
+import (
+	"errors"
+	"sync"
+
+	"golang.org/x/exp/constraints"
+)
+
+const memoizeObserverName = "sortedmap.memoize"
+
+var ErrLoaderPanicked = errors.New("loader panicked")
+
+type memoCall[T any] struct {
+	wg    sync.WaitGroup
+	value T
+	err   error
+}
+
+// MemoizedSortedMap is a map which loads missing values on Get using a loader function.
+type MemoizedSortedMap[K constraints.Ordered, T any] struct {
+	*SortedMap[K, T]
+
+	loader func(K) (T, error)
+	mu     sync.Mutex
+	calls  map[K]*memoCall[T]
+	// loaded holds the keys stored by the loader, it is protected by the lock of the map.
+	loaded map[K]struct{}
+}
+
+// memoObserver forgets loaded keys once they are set or deleted by anything else than the loader.
+type memoObserver[K constraints.Ordered, T any] struct {
+	m *MemoizedSortedMap[K, T]
+}
+
+func (o memoObserver[K, T]) OnSet(key K, _ T, _ bool, _ T) {
+	delete(o.m.loaded, key)
+}
+
+func (o memoObserver[K, T]) OnDelete(key K, _ T) {
+	delete(o.m.loaded, key)
+}
+
+// Memoize returns a map using sm as cache for the values returned by loader. Concurrent Get calls for the same missing
+// key call loader only once, values are not stored if loader returns an error. If loader panics, the panic is
+// propagated to the caller that invoked it and the concurrent callers get ErrLoaderPanicked.
+func (sm *SortedMap[K, T]) Memoize(loader func(K) (T, error)) *MemoizedSortedMap[K, T] {
+	m := &MemoizedSortedMap[K, T]{
+		SortedMap: sm,
+		loader:    loader,
+		calls:     make(map[K]*memoCall[T]),
+		loaded:    make(map[K]struct{}),
+	}
+
+	sm.Register(memoizeObserverName, memoObserver[K, T]{m: m})
+
+	return m
+}
+
+// Get returns the value stored for key, loading and storing it first if key is not present.
+func (m *MemoizedSortedMap[K, T]) Get(key K) (T, error) {
+	if value, err := m.SortedMap.Get(key); err == nil {
+		return value, nil
+	}
+
+	m.mu.Lock()
+
+	if c, exists := m.calls[key]; exists {
+		m.mu.Unlock()
+		c.wg.Wait()
+
+		return c.value, c.err
+	}
+
+	if value, err := m.SortedMap.Get(key); err == nil {
+		m.mu.Unlock()
+
+		return value, nil
+	}
+
+	c := &memoCall[T]{}
+	c.wg.Add(1)
+	m.calls[key] = c
+
+	m.mu.Unlock()
+
+	defer func() {
+		m.mu.Lock()
+		delete(m.calls, key)
+		m.mu.Unlock()
+
+		c.wg.Done()
+	}()
+
+	// concurrent callers get this error if loader panics
+	c.err = ErrLoaderPanicked
+
+	c.value, c.err = m.loader(key)
+	if c.err == nil {
+		c.err = m.store(key, c.value)
+	}
+
+	if c.err != nil {
+		var zero T
+
+		c.value = zero
+	}
+
+	return c.value, c.err
+}
+
+func (m *MemoizedSortedMap[K, T]) store(key K, value T) error {
+	m.SortedMap.mu.Lock()
+	defer m.SortedMap.mu.Unlock()
+
+	if err := m.set(key, value); err != nil {
+		return err
+	}
+
+	m.loaded[key] = struct{}{}
+
+	return nil
+}
+
+// HasLoaded reports whether the value of key was stored by the loader and not set or deleted since.
+func (m *MemoizedSortedMap[K, T]) HasLoaded(key K) bool {
+	m.SortedMap.mu.RLock()
+	defer m.SortedMap.mu.RUnlock()
+
+	_, loaded := m.loaded[key]
+
+	return loaded
+}
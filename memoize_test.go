@@ -0,0 +1,126 @@
+package sortedmap_test
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortedMap_Memoize(t *testing.T) {
+	errNegative := errors.New("negative")
+
+	var calls atomic.Int32
+
+	m := sortedmap.New[int, string]().
+		Set(1, "set").
+		Memoize(func(key int) (string, error) {
+			calls.Add(1)
+
+			if key < 0 {
+				return "", errNegative
+			}
+
+			return strconv.Itoa(key), nil
+		})
+
+	value, err := m.Get(1)
+	require.NoError(t, err)
+	assert.Equal(t, "set", value)
+	assert.False(t, m.HasLoaded(1))
+
+	value, err = m.Get(2)
+	require.NoError(t, err)
+	assert.Equal(t, "2", value)
+	assert.True(t, m.HasLoaded(2))
+	assert.True(t, m.Has(2))
+
+	_, err = m.Get(-1)
+	assert.ErrorIs(t, err, errNegative)
+	assert.False(t, m.Has(-1))
+
+	m.Set(2, "set")
+	assert.False(t, m.HasLoaded(2))
+
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestSortedMap_Memoize_SingleFlight(t *testing.T) {
+	var (
+		calls   atomic.Int32
+		wg      sync.WaitGroup
+		release = make(chan struct{})
+	)
+
+	m := sortedmap.New[string, int]().Memoize(func(string) (int, error) {
+		calls.Add(1)
+		<-release
+
+		return 42, nil
+	})
+
+	for range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			value, err := m.Get("key")
+			assert.NoError(t, err)
+			assert.Equal(t, 42, value)
+		}()
+	}
+
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestSortedMap_Memoize_LoaderPanics(t *testing.T) {
+	var once sync.Once
+
+	started, release := make(chan struct{}), make(chan struct{})
+
+	m := sortedmap.New[string, int]().Memoize(func(string) (int, error) {
+		once.Do(func() {
+			close(started)
+		})
+		<-release
+
+		panic("boom")
+	})
+
+	panicked := make(chan any)
+
+	go func() {
+		defer func() {
+			panicked <- recover()
+		}()
+
+		_, _ = m.Get("key")
+	}()
+
+	<-started
+
+	waited := make(chan error)
+
+	go func() {
+		_, err := m.Get("key")
+		waited <- err
+	}()
+
+	// give the second caller time to wait for the running load
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	assert.Equal(t, "boom", <-panicked)
+	assert.ErrorIs(t, <-waited, sortedmap.ErrLoaderPanicked)
+	assert.False(t, m.Has("key"))
+}
@@ -0,0 +1,122 @@
+package sortedmap
+
+import (
+	"container/heap"
+	"slices"
+	"unsafe"
+
+	"golang.org/x/exp/constraints"
+)
+
+// lockAll read locks maps in pointer-address order to avoid deadlocks, the returned function releases all locks.
+func lockAll[K constraints.Ordered, T any](maps []*SortedMap[K, T]) func() {
+	sorted := slices.Clone(maps)
+
+	slices.SortFunc(sorted, func(a, b *SortedMap[K, T]) int {
+		return compareKeys(uintptr(unsafe.Pointer(a)), uintptr(unsafe.Pointer(b)), ascending)
+	})
+
+	sorted = slices.Compact(sorted)
+
+	for _, sm := range sorted {
+		sm.mu.RLock()
+	}
+
+	return func() {
+		for i := len(sorted) - 1; i >= 0; i-- {
+			sorted[i].mu.RUnlock()
+		}
+	}
+}
+
+type mergeCursor[K constraints.Ordered] struct {
+	keys  []K
+	pos   int
+	index int
+}
+
+// mergeHeap orders the cursors by their current key, and by the index of their map for equal keys.
+type mergeHeap[K constraints.Ordered] struct {
+	cursors   []*mergeCursor[K]
+	direction int8
+}
+
+func (h *mergeHeap[K]) Len() int {
+	return len(h.cursors)
+}
+
+func (h *mergeHeap[K]) Less(i, j int) bool {
+	a, b := h.cursors[i], h.cursors[j]
+
+	if c := compareKeys(a.keys[a.pos], b.keys[b.pos], h.direction); c != 0 {
+		return c < 0
+	}
+
+	return a.index < b.index
+}
+
+func (h *mergeHeap[K]) Swap(i, j int) {
+	h.cursors[i], h.cursors[j] = h.cursors[j], h.cursors[i]
+}
+
+func (h *mergeHeap[K]) Push(x any) {
+	h.cursors = append(h.cursors, x.(*mergeCursor[K]))
+}
+
+func (h *mergeHeap[K]) Pop() any {
+	last := h.cursors[len(h.cursors)-1]
+	h.cursors = h.cursors[:len(h.cursors)-1]
+
+	return last
+}
+
+// MergeAll returns a map with the entries of all maps, the value of the last map holding a key wins. The maps are
+// merged in a single pass, and the result is sorted in the direction of the first map.
+func MergeAll[K constraints.Ordered, T any](maps ...*SortedMap[K, T]) *SortedMap[K, T] {
+	if len(maps) == 0 {
+		return New[K, T]()
+	}
+
+	unlock := lockAll(maps)
+	defer unlock()
+
+	var total int
+	for _, sm := range maps {
+		total += len(sm.sortedKeys)
+	}
+
+	result := maps[0].newLike(total)
+
+	h := &mergeHeap[K]{direction: result.direction}
+
+	for i, sm := range maps {
+		if len(sm.sortedKeys) > 0 {
+			h.cursors = append(h.cursors, &mergeCursor[K]{keys: sm.keysIn(result.direction), index: i})
+		}
+	}
+
+	heap.Init(h)
+
+	for h.Len() > 0 {
+		key := h.cursors[0].keys[h.cursors[0].pos]
+
+		// equal keys are ordered by map index, so the last cursor holding key is the winner
+		var winner int
+
+		for h.Len() > 0 && h.cursors[0].keys[h.cursors[0].pos] == key {
+			c := h.cursors[0]
+			winner = c.index
+
+			c.pos++
+			if c.pos < len(c.keys) {
+				heap.Fix(h, 0)
+			} else {
+				heap.Pop(h)
+			}
+		}
+
+		result.push(key, maps[winner].items[key])
+	}
+
+	return result
+}
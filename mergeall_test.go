@@ -0,0 +1,36 @@
+package sortedmap_test
+
+import (
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeAll(t *testing.T) {
+	a := sortedmap.New[int, string]().
+		Set(1, "a1").
+		Set(4, "a4").
+		Set(7, "a7")
+
+	b := sortedmap.New[int, string]().
+		Set(2, "b2").
+		Set(4, "b4")
+
+	c := sortedmap.New(sortedmap.WithDescending[int, string]()).
+		Set(4, "c4").
+		Set(7, "c7").
+		Set(9, "c9")
+
+	merged := sortedmap.MergeAll(a, b, c, sortedmap.New[int, string]())
+
+	assert.Equal(t, []int{1, 2, 4, 7, 9}, merged.Keys())
+	assert.Equal(t, []string{"a1", "b2", "c4", "c7", "c9"}, merged.Values())
+
+	merged = sortedmap.MergeAll(c, b, a, a)
+
+	assert.Equal(t, []int{9, 7, 4, 2, 1}, merged.Keys())
+	assert.Equal(t, []string{"c9", "a7", "a4", "b2", "a1"}, merged.Values())
+
+	assert.Equal(t, 0, sortedmap.MergeAll[int, string]().Len())
+}
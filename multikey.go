@@ -0,0 +1,175 @@
+package sortedmap
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"math"
+	"reflect"
+
+	"golang.org/x/exp/constraints"
+)
+
+var ErrInvalidCompositeKey = errors.New("invalid composite key")
+
+// MultiKey is a key made of two ordered values, ordered by First, then by Second.
+type MultiKey[K1, K2 constraints.Ordered] struct {
+	First  K1
+	Second K2
+}
+
+// Compare compares two keys by their First fields, then by their Second fields, and returns -1, 0 or 1.
+func (m MultiKey[K1, K2]) Compare(other MultiKey[K1, K2]) int {
+	if c := cmp.Compare(m.First, other.First); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(m.Second, other.Second)
+}
+
+// Compose encodes the key as a CompositeKey, preserving its order.
+func (m MultiKey[K1, K2]) Compose() CompositeKey[K1, K2] {
+	return Compose(m.First, m.Second)
+}
+
+// CompositeKey is an order preserving encoding of two ordered values, comparing two composite keys gives the same
+// result as comparing the MultiKey values they were composed from. Being a string, it can be used as the key type of
+// a SortedMap.
+type CompositeKey[K1, K2 constraints.Ordered] string
+
+// Compose encodes k1 and k2 as a CompositeKey. Composite keys compare as strings in the same order as the values they
+// were composed from, first by k1, then by k2, so they can be used to sort a SortedMap by two values.
+func Compose[K1, K2 constraints.Ordered](k1 K1, k2 K2) CompositeKey[K1, K2] {
+	b := appendOrdered(nil, k1)
+	b = appendOrdered(b, k2)
+
+	return CompositeKey[K1, K2](b)
+}
+
+// Decompose decodes the values c was composed from, it fails with ErrInvalidCompositeKey if c was not created by
+// Compose.
+func (c CompositeKey[K1, K2]) Decompose() (K1, K2, error) {
+	var (
+		k1 K1
+		k2 K2
+	)
+
+	rest, err := readOrdered(&k1, []byte(c))
+	if err != nil {
+		return k1, k2, err
+	}
+
+	rest, err = readOrdered(&k2, rest)
+	if err != nil {
+		return k1, k2, err
+	}
+
+	if len(rest) > 0 {
+		return k1, k2, ErrInvalidCompositeKey
+	}
+
+	return k1, k2, nil
+}
+
+// MultiKey works like Decompose, but returns the values as a MultiKey.
+func (c CompositeKey[K1, K2]) MultiKey() (MultiKey[K1, K2], error) {
+	k1, k2, err := c.Decompose()
+
+	return MultiKey[K1, K2]{First: k1, Second: k2}, err
+}
+
+const signBit = 1 << 63
+
+// appendOrdered appends an encoding of k to b which preserves the ordering of values of the same type. Strings are
+// terminated by 0x00 0x01 with 0x00 bytes escaped as 0x00 0xFF, numbers are encoded on 8 bytes.
+func appendOrdered[K constraints.Ordered](b []byte, k K) []byte {
+	v := reflect.ValueOf(k)
+
+	switch v.Kind() {
+	case reflect.String:
+		for _, c := range []byte(v.String()) {
+			if c == 0 {
+				b = append(b, 0, 0xff)
+
+				continue
+			}
+
+			b = append(b, c)
+		}
+
+		return append(b, 0, 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binary.BigEndian.AppendUint64(b, uint64(v.Int())^signBit)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return binary.BigEndian.AppendUint64(b, v.Uint())
+	default:
+		f := v.Float()
+		if f == 0 {
+			// -0 and 0 are equal
+			f = 0
+		}
+
+		bits := math.Float64bits(f)
+		if bits&signBit != 0 {
+			bits = ^bits
+		} else {
+			bits |= signBit
+		}
+
+		return binary.BigEndian.AppendUint64(b, bits)
+	}
+}
+
+// readOrdered decodes a value encoded by appendOrdered into k and returns the remaining bytes.
+func readOrdered[K constraints.Ordered](k *K, b []byte) ([]byte, error) {
+	v := reflect.ValueOf(k).Elem()
+
+	if v.Kind() == reflect.String {
+		var s []byte
+
+		for {
+			i := bytes.IndexByte(b, 0)
+			if i < 0 || i+1 >= len(b) {
+				return nil, ErrInvalidCompositeKey
+			}
+
+			s = append(s, b[:i]...)
+
+			switch b[i+1] {
+			case 0xff:
+				s = append(s, 0)
+				b = b[i+2:]
+			case 1:
+				v.SetString(string(s))
+
+				return b[i+2:], nil
+			default:
+				return nil, ErrInvalidCompositeKey
+			}
+		}
+	}
+
+	if len(b) < 8 {
+		return nil, ErrInvalidCompositeKey
+	}
+
+	n, rest := binary.BigEndian.Uint64(b), b[8:]
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(n ^ signBit))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(n)
+	default:
+		if n&signBit != 0 {
+			n &^= signBit
+		} else {
+			n = ^n
+		}
+
+		v.SetFloat(math.Float64frombits(n))
+	}
+
+	return rest, nil
+}
@@ -0,0 +1,103 @@
+package sortedmap_test
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiKey_Compare(t *testing.T) {
+	a := sortedmap.MultiKey[string, int]{First: "ns1", Second: 2}
+
+	assert.Equal(t, 0, a.Compare(sortedmap.MultiKey[string, int]{First: "ns1", Second: 2}))
+	assert.Equal(t, -1, a.Compare(sortedmap.MultiKey[string, int]{First: "ns1", Second: 3}))
+	assert.Equal(t, 1, a.Compare(sortedmap.MultiKey[string, int]{First: "ns0", Second: 3}))
+}
+
+func TestCompose(t *testing.T) {
+	sm := sortedmap.New[sortedmap.CompositeKey[string, int], string]().
+		Set(sortedmap.Compose("ns2", 1), "c").
+		Set(sortedmap.Compose("ns1", 10), "b").
+		Set(sortedmap.Compose("ns1", -5), "a").
+		Set(sortedmap.Compose("ns10", 0), "d")
+
+	assert.Equal(t, []string{"a", "b", "d", "c"}, sm.Values())
+
+	first, second, err := sm.Keys()[1].Decompose()
+	require.NoError(t, err)
+	assert.Equal(t, "ns1", first)
+	assert.Equal(t, 10, second)
+}
+
+func TestCompose_PreservesOrder(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+
+	strs := []string{"", "a", "a\x00", "a\x00b", "a\x01", "ab", "b", "\x00", "\xff"}
+	floats := []float64{math.Inf(-1), -1e10, -1.5, -0.0, 0, 1e-300, 2.5, math.MaxFloat64, math.Inf(1)}
+
+	keys := make([]sortedmap.MultiKey[string, float64], 0, 200)
+	for range 200 {
+		keys = append(keys, sortedmap.MultiKey[string, float64]{
+			First:  strs[rng.Intn(len(strs))],
+			Second: floats[rng.Intn(len(floats))],
+		})
+	}
+
+	for _, a := range keys {
+		for _, b := range keys {
+			ca, cb := a.Compose(), b.Compose()
+
+			expected := a.Compare(b)
+			actual := 0
+			if ca < cb {
+				actual = -1
+			} else if ca > cb {
+				actual = 1
+			}
+
+			require.Equal(t, expected, actual, "%#v %#v", a, b)
+		}
+	}
+
+	for _, key := range keys {
+		actual, err := key.Compose().MultiKey()
+		require.NoError(t, err)
+		assert.Equal(t, key, actual)
+	}
+}
+
+func TestCompose_Integers(t *testing.T) {
+	values := []int64{math.MinInt64, -300, -1, 0, 1, 255, math.MaxInt64}
+
+	composed := make([]sortedmap.CompositeKey[int64, uint8], 0, len(values))
+	for i := len(values) - 1; i >= 0; i-- {
+		composed = append(composed, sortedmap.Compose(values[i], uint8(i)))
+	}
+
+	sort.Slice(composed, func(i, j int) bool {
+		return composed[i] < composed[j]
+	})
+
+	for i, c := range composed {
+		first, second, err := c.Decompose()
+		require.NoError(t, err)
+		assert.Equal(t, values[i], first)
+		assert.Equal(t, uint8(i), second)
+	}
+}
+
+func TestCompositeKey_DecomposeInvalid(t *testing.T) {
+	_, _, err := sortedmap.CompositeKey[string, int]("abc").Decompose()
+	assert.ErrorIs(t, err, sortedmap.ErrInvalidCompositeKey)
+
+	_, _, err = sortedmap.CompositeKey[int, int]("short").Decompose()
+	assert.ErrorIs(t, err, sortedmap.ErrInvalidCompositeKey)
+
+	_, _, err = (sortedmap.Compose(1, 2) + "x").Decompose()
+	assert.ErrorIs(t, err, sortedmap.ErrInvalidCompositeKey)
+}
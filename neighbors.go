@@ -0,0 +1,40 @@
+package sortedmap
+
+// Predecessor returns the entry right before key in the order of the map, e.g. the largest key smaller than key in an
+// ascending map.
+func (sm *SortedMap[K, T]) Predecessor(key K) (K, T, bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	return sm.entryAt(searchSorted(sm.sortedKeys, key, sm.direction) - 1)
+}
+
+// ClosedPredecessor is like Predecessor, but returns the entry of key itself if it is present.
+func (sm *SortedMap[K, T]) ClosedPredecessor(key K) (K, T, bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	return sm.entryAt(floorIndex(sm.sortedKeys, key, sm.direction))
+}
+
+// Successor returns the entry right after key in the order of the map, e.g. the smallest key greater than key in an
+// ascending map.
+func (sm *SortedMap[K, T]) Successor(key K) (K, T, bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	i := searchSorted(sm.sortedKeys, key, sm.direction)
+	if i < len(sm.sortedKeys) && sm.sortedKeys[i] == key {
+		i++
+	}
+
+	return sm.entryAt(i)
+}
+
+// ClosedSuccessor is like Successor, but returns the entry of key itself if it is present.
+func (sm *SortedMap[K, T]) ClosedSuccessor(key K) (K, T, bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	return sm.entryAt(searchSorted(sm.sortedKeys, key, sm.direction))
+}
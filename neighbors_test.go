@@ -0,0 +1,62 @@
+package sortedmap_test
+
+import (
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortedMap_Predecessor(t *testing.T) {
+	sm := sortedmap.New[int, string]().
+		Set(30, "thirty").
+		Set(10, "ten").
+		Set(20, "twenty")
+
+	tests := []struct {
+		name          string
+		method        func(int) (int, string, bool)
+		key           int
+		expectedKey   int
+		expectedValue string
+		expectedOk    bool
+	}{
+		{name: "predecessor of present key", method: sm.Predecessor, key: 20, expectedKey: 10, expectedValue: "ten", expectedOk: true},
+		{name: "predecessor of missing key", method: sm.Predecessor, key: 25, expectedKey: 20, expectedValue: "twenty", expectedOk: true},
+		{name: "predecessor of first key", method: sm.Predecessor, key: 10},
+		{name: "closed predecessor of present key", method: sm.ClosedPredecessor, key: 20, expectedKey: 20, expectedValue: "twenty", expectedOk: true},
+		{name: "closed predecessor of missing key", method: sm.ClosedPredecessor, key: 35, expectedKey: 30, expectedValue: "thirty", expectedOk: true},
+		{name: "closed predecessor before first key", method: sm.ClosedPredecessor, key: 5},
+		{name: "successor of present key", method: sm.Successor, key: 20, expectedKey: 30, expectedValue: "thirty", expectedOk: true},
+		{name: "successor of missing key", method: sm.Successor, key: 15, expectedKey: 20, expectedValue: "twenty", expectedOk: true},
+		{name: "successor of last key", method: sm.Successor, key: 30},
+		{name: "closed successor of present key", method: sm.ClosedSuccessor, key: 20, expectedKey: 20, expectedValue: "twenty", expectedOk: true},
+		{name: "closed successor of missing key", method: sm.ClosedSuccessor, key: 5, expectedKey: 10, expectedValue: "ten", expectedOk: true},
+		{name: "closed successor after last key", method: sm.ClosedSuccessor, key: 35},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, value, ok := tt.method(tt.key)
+
+			assert.Equal(t, tt.expectedKey, key)
+			assert.Equal(t, tt.expectedValue, value)
+			assert.Equal(t, tt.expectedOk, ok)
+		})
+	}
+}
+
+func TestSortedMap_Successor_Descending(t *testing.T) {
+	sm := sortedmap.New(sortedmap.WithDescending[int, string]()).
+		Set(10, "ten").
+		Set(20, "twenty").
+		Set(30, "thirty")
+
+	key, _, ok := sm.Successor(20)
+	assert.True(t, ok)
+	assert.Equal(t, 10, key)
+
+	key, _, ok = sm.Predecessor(20)
+	assert.True(t, ok)
+	assert.Equal(t, 30, key)
+}
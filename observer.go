@@ -0,0 +1,82 @@
+package sortedmap
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+// Observer is notified synchronously after each modification of a map. The observers are called while the write lock
+// of the map is held, so they must not access the map itself.
+type Observer[K constraints.Ordered, T any] interface {
+	OnSet(key K, old T, oldExists bool, new T)
+	OnDelete(key K, value T)
+}
+
+// Register adds an observer under the given name, replacing any observer previously registered with the same name.
+func (sm *SortedMap[K, T]) Register(name string, o Observer[K, T]) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if sm.observers == nil {
+		sm.observers = make(map[string]Observer[K, T])
+	}
+
+	if _, exists := sm.observers[name]; !exists {
+		sm.observerNames = insertSorted(sm.observerNames, name, ascending)
+	}
+
+	sm.observers[name] = o
+}
+
+// Unregister removes the observer registered with name, unregistering an unknown name is a no-op.
+func (sm *SortedMap[K, T]) Unregister(name string) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if _, exists := sm.observers[name]; !exists {
+		return
+	}
+
+	delete(sm.observers, name)
+
+	sm.observerNames = deleteSorted(sm.observerNames, name, ascending)
+}
+
+func (sm *SortedMap[K, T]) notifySet(key K, old T, oldExists bool, value T) {
+	if len(sm.observers) == 0 {
+		return
+	}
+
+	for _, name := range sm.observerNames {
+		sm.observers[name].OnSet(key, old, oldExists, value)
+	}
+}
+
+func (sm *SortedMap[K, T]) notifyDelete(key K, value T) {
+	if len(sm.observers) == 0 {
+		return
+	}
+
+	for _, name := range sm.observerNames {
+		sm.observers[name].OnDelete(key, value)
+	}
+}
+
+// notifyReplaced notifies the observers after the whole content of the map was replaced, oldItems and oldKeys being the
+// previous content.
+func (sm *SortedMap[K, T]) notifyReplaced(oldItems map[K]T, oldKeys []K) {
+	if len(sm.observers) == 0 {
+		return
+	}
+
+	for _, key := range oldKeys {
+		if !sm.has(key) {
+			sm.notifyDelete(key, oldItems[key])
+		}
+	}
+
+	for _, key := range sm.sortedKeys {
+		old, exists := oldItems[key]
+
+		sm.notifySet(key, old, exists, sm.items[key])
+	}
+}
@@ -0,0 +1,78 @@
+package sortedmap_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingObserver struct {
+	name   string
+	events *[]string
+}
+
+func (o recordingObserver) OnSet(key string, old int, oldExists bool, new int) {
+	*o.events = append(*o.events, fmt.Sprintf("%s: set %s %d %t %d", o.name, key, old, oldExists, new))
+}
+
+func (o recordingObserver) OnDelete(key string, value int) {
+	*o.events = append(*o.events, fmt.Sprintf("%s: delete %s %d", o.name, key, value))
+}
+
+func TestSortedMap_Register(t *testing.T) {
+	var events []string
+
+	sm := sortedmap.New[string, int]()
+	sm.Register("cache", recordingObserver{name: "cache", events: &events})
+	sm.Register("audit", recordingObserver{name: "audit", events: &events})
+
+	sm.Set("key1", 1).Set("key1", 2).Delete("key1", "nope")
+
+	assert.Equal(t, []string{
+		"audit: set key1 0 false 1",
+		"cache: set key1 0 false 1",
+		"audit: set key1 1 true 2",
+		"cache: set key1 1 true 2",
+		"audit: delete key1 2",
+		"cache: delete key1 2",
+	}, events)
+
+	events = nil
+
+	sm.Unregister("cache")
+	sm.Unregister("nope")
+
+	sm.Set("key2", 2).RetainIf(func(string, int) bool {
+		return false
+	})
+
+	assert.Equal(t, []string{
+		"audit: set key2 0 false 2",
+		"audit: delete key2 2",
+	}, events)
+}
+
+func TestSortedMap_Register_ReadFrom(t *testing.T) {
+	var buf bytes.Buffer
+
+	_, err := sortedmap.New[string, int]().Set("key2", 20).Set("key3", 3).WriteTo(&buf)
+	require.NoError(t, err)
+
+	var events []string
+
+	sm := sortedmap.New[string, int]().Set("key1", 1).Set("key2", 2)
+	sm.Register("audit", recordingObserver{name: "audit", events: &events})
+
+	_, err = sm.ReadFrom(&buf)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"audit: delete key1 1",
+		"audit: set key2 2 true 20",
+		"audit: set key3 0 false 3",
+	}, events)
+}
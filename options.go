@@ -0,0 +1,101 @@
+package sortedmap
+
+import (
+	"sync"
+
+	"golang.org/x/exp/constraints"
+)
+
+type Option[K constraints.Ordered, T any] func(*SortedMap[K, T])
+
+// WithValidator registers a validator called before storing any key-value pair, all validators must pass. Set ignores
+// rejected pairs, while SetE and the other methods returning errors return the error of the first failing validator.
+func WithValidator[K constraints.Ordered, T any](validator func(K, T) error) Option[K, T] {
+	return func(sm *SortedMap[K, T]) {
+		sm.validators = append(sm.validators, validator)
+	}
+}
+
+// EvictionPolicy decides which entry is evicted when a new key is added to a full map. The policies refer to the values
+// of the keys, not to their position, so they evict the same key in ascending and descending maps.
+type EvictionPolicy int
+
+const (
+	// EvictNone evicts nothing, adding a new key to a full map fails with ErrMapFull.
+	EvictNone EvictionPolicy = iota
+	// EvictSmallest evicts the smallest key, which is the last key of a descending map.
+	EvictSmallest
+	// EvictLargest evicts the largest key, which is the first key of a descending map.
+	EvictLargest
+)
+
+// WithMaxSize limits the number of entries in the map, 0 means no limit. Adding a new key to a full map fails with
+// ErrMapFull, unless an eviction policy is set, in which case an entry is evicted first and passed to onEvict.
+func WithMaxSize[K constraints.Ordered, T any](limit int, onEvict func(K, T)) Option[K, T] {
+	return func(sm *SortedMap[K, T]) {
+		sm.maxSize = limit
+		sm.onEvict = onEvict
+	}
+}
+
+// WithEvictionPolicy sets the policy used to make room in a map limited by WithMaxSize, the default is EvictNone.
+func WithEvictionPolicy[K constraints.Ordered, T any](policy EvictionPolicy) Option[K, T] {
+	return func(sm *SortedMap[K, T]) {
+		sm.policy = policy
+	}
+}
+
+// WithDescending keeps the keys of the map in descending order. Methods walking, seeking or comparing keys follow the
+// order of the map, so the first key is the largest one, while eviction policies still refer to the key values.
+func WithDescending[K constraints.Ordered, T any]() Option[K, T] {
+	return func(sm *SortedMap[K, T]) {
+		sm.direction = descending
+	}
+}
+
+// RWLocker is the lock used by a map, sync.RWMutex implements it.
+type RWLocker interface {
+	sync.Locker
+	RLock()
+	RUnlock()
+}
+
+// exclusiveLocker turns a sync.Locker into an RWLocker by using the exclusive lock for readers too.
+type exclusiveLocker struct {
+	sync.Locker
+}
+
+func (l exclusiveLocker) RLock() {
+	l.Lock()
+}
+
+func (l exclusiveLocker) RUnlock() {
+	l.Unlock()
+}
+
+type noLock struct{}
+
+func (noLock) Lock()    {}
+func (noLock) Unlock()  {}
+func (noLock) RLock()   {}
+func (noLock) RUnlock() {}
+
+// WithMutex replaces the lock of the map with mu. If mu does not implement RWLocker, readers use its exclusive lock.
+func WithMutex[K constraints.Ordered, T any](mu sync.Locker) Option[K, T] {
+	return func(sm *SortedMap[K, T]) {
+		if rw, ok := mu.(RWLocker); ok {
+			sm.mu = rw
+
+			return
+		}
+
+		sm.mu = exclusiveLocker{mu}
+	}
+}
+
+// WithNoLock disables locking, the map must not be used by multiple goroutines concurrently.
+func WithNoLock[K constraints.Ordered, T any]() Option[K, T] {
+	return func(sm *SortedMap[K, T]) {
+		sm.mu = noLock{}
+	}
+}
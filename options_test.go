@@ -0,0 +1,259 @@
+package sortedmap_test
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	errEmptyValue     = errors.New("empty value")
+	errForbiddenChars = errors.New("forbidden characters")
+)
+
+func notEmpty(_ string, value string) error {
+	if value == "" {
+		return errEmptyValue
+	}
+
+	return nil
+}
+
+func noSlashes(key string, _ string) error {
+	if strings.Contains(key, "/") {
+		return errForbiddenChars
+	}
+
+	return nil
+}
+
+func TestWithValidator(t *testing.T) {
+	sm := sortedmap.New(
+		sortedmap.WithValidator(notEmpty),
+		sortedmap.WithValidator(noSlashes),
+	)
+
+	_, err := sm.SetE("key1", "value1")
+	require.NoError(t, err)
+
+	_, err = sm.SetE("key2", "")
+	assert.ErrorIs(t, err, errEmptyValue)
+
+	_, err = sm.SetE("key/3", "value3")
+	assert.ErrorIs(t, err, errForbiddenChars)
+
+	sm.Set("key4", "").Set("key5", "value5")
+
+	_, inserted := sm.SetDefault("key6", "")
+	assert.False(t, inserted)
+
+	assert.Equal(t, []string{"key1", "key5"}, sm.Keys())
+}
+
+func TestWithValidator_Import(t *testing.T) {
+	sm := sortedmap.NewWithCapacity(10, sortedmap.WithValidator(notEmpty))
+
+	err := sm.ImportJSON(strings.NewReader(`{"key1": "value1", "key2": ""}`))
+	assert.ErrorIs(t, err, errEmptyValue)
+
+	err = sm.ImportYAML(strings.NewReader("key3: value3\nkey4: ''\n"))
+	assert.ErrorIs(t, err, errEmptyValue)
+
+	// imports set all entries or none of them
+	assert.Empty(t, sm.Keys())
+}
+
+func TestWithMaxSize(t *testing.T) {
+	sm := sortedmap.New(sortedmap.WithMaxSize[string, int](2, nil)).
+		Set("key1", 1).
+		Set("key2", 2)
+
+	_, err := sm.SetE("key3", 3)
+	assert.ErrorIs(t, err, sortedmap.ErrMapFull)
+
+	_, err = sm.SetE("key2", -2)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"key1", "key2"}, sm.Keys())
+	assert.Equal(t, []int{1, -2}, sm.Values())
+}
+
+func TestWithMaxSize_NoLimit(t *testing.T) {
+	sm := sortedmap.New(sortedmap.WithMaxSize[int, int](0, nil))
+
+	for i := range 10 {
+		_, err := sm.SetE(i, i)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 10, sm.Len())
+}
+
+func TestWithEvictionPolicy(t *testing.T) {
+	tests := []struct {
+		name            string
+		policy          sortedmap.EvictionPolicy
+		expectedKeys    []string
+		expectedEvicted []string
+	}{
+		{
+			name:            "smallest",
+			policy:          sortedmap.EvictSmallest,
+			expectedKeys:    []string{"key3", "key4"},
+			expectedEvicted: []string{"key1", "key2"},
+		},
+		{
+			name:            "largest",
+			policy:          sortedmap.EvictLargest,
+			expectedKeys:    []string{"key1", "key4"},
+			expectedEvicted: []string{"key2", "key3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var evicted []string
+
+			sm := sortedmap.New(
+				sortedmap.WithMaxSize(2, func(key string, _ int) {
+					evicted = append(evicted, key)
+				}),
+				sortedmap.WithEvictionPolicy[string, int](tt.policy),
+			)
+
+			for i, key := range []string{"key1", "key2", "key3", "key4"} {
+				_, err := sm.SetE(key, i)
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.expectedKeys, sm.Keys())
+			assert.Equal(t, tt.expectedEvicted, evicted)
+		})
+	}
+}
+
+func TestWithDescending(t *testing.T) {
+	sm := sortedmap.New(sortedmap.WithDescending[int, string]()).
+		Set(2, "two").
+		Set(4, "four").
+		Set(1, "one").
+		Set(3, "three").
+		Delete(2)
+
+	assert.Equal(t, []int{4, 3, 1}, sm.Keys())
+	assert.Equal(t, []string{"four", "three", "one"}, sm.Values())
+
+	var walked []int
+	sm.WalkFrom(2, func(key int, _ string) bool {
+		walked = append(walked, key)
+
+		return true
+	})
+	assert.Equal(t, []int{1}, walked)
+
+	walked = nil
+	sm.WalkFromDesc(2, func(key int, _ string) bool {
+		walked = append(walked, key)
+
+		return true
+	})
+	assert.Equal(t, []int{3, 4}, walked)
+
+	assert.Equal(t, []int{3, 1}, sm.Drop(1).Keys())
+}
+
+func TestWithDescending_SetOperations(t *testing.T) {
+	desc := sortedmap.New(sortedmap.WithDescending[int, int]()).
+		Set(1, 1).
+		Set(2, 2).
+		Set(3, 3)
+
+	asc := sortedmap.New[int, int]().
+		Set(2, 20).
+		Set(3, 30).
+		Set(4, 40)
+
+	assert.Equal(t, []int{4, 3, 2, 1}, desc.UnionWith(asc, nil).Keys())
+	assert.Equal(t, []int{30, 20}, desc.IntersectWith(asc, func(_, b int) int { return b }).Values())
+	assert.Equal(t, []int{4, 1}, desc.SymmetricDifference(asc).Keys())
+	assert.Equal(t, []int{4, 3, 2}, desc.RightJoin(asc, 0, nil).Keys())
+	assert.Equal(t, []int{1, 2, 3, 4}, asc.UnionWith(desc, nil).Keys())
+}
+
+func TestWithDescending_Eviction(t *testing.T) {
+	var evicted []int
+
+	sm := sortedmap.New(
+		sortedmap.WithDescending[int, int](),
+		sortedmap.WithMaxSize(2, func(key int, _ int) {
+			evicted = append(evicted, key)
+		}),
+		sortedmap.WithEvictionPolicy[int, int](sortedmap.EvictSmallest),
+	)
+
+	sm.Set(1, 1).Set(2, 2).Set(3, 3)
+
+	assert.Equal(t, []int{3, 2}, sm.Keys())
+	assert.Equal(t, []int{1}, evicted)
+}
+
+type countingMutex struct {
+	sync.Mutex
+	locks int
+}
+
+func (m *countingMutex) Lock() {
+	m.Mutex.Lock()
+	m.locks++
+}
+
+func TestWithMutex(t *testing.T) {
+	mu := &countingMutex{}
+
+	sm := sortedmap.New(sortedmap.WithMutex[string, int](mu)).
+		Set("key1", 1).
+		Set("key2", 2)
+
+	assert.Equal(t, 1, sm.MustGet("key1"))
+	assert.Equal(t, 2, sm.Len())
+	assert.Equal(t, 4, mu.locks)
+}
+
+func TestWithMutex_RWLocker(t *testing.T) {
+	var wg sync.WaitGroup
+
+	sm := sortedmap.New(sortedmap.WithMutex[int, int](&sync.RWMutex{}))
+
+	for i := range 100 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			sm.Set(i, i)
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 100, sm.Len())
+}
+
+func TestWithNoLock(t *testing.T) {
+	sm := sortedmap.New(sortedmap.WithNoLock[string, int]()).
+		Set("key2", 2).
+		Set("key1", 1)
+
+	assert.Equal(t, []string{"key1", "key2"}, sm.Keys())
+
+	sm.BatchUpdate(func(draft *sortedmap.SortedMap[string, int]) {
+		draft.Delete("key1")
+	})
+
+	assert.Equal(t, []string{"key2"}, sm.Keys())
+}
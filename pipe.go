@@ -0,0 +1,30 @@
+package sortedmap
+
+// Pipe calls each transform with the result of the previous one, starting with sm, and returns the last result. Pipe
+// holds no lock itself, transforms are expected to lock the maps they use.
+func (sm *SortedMap[K, T]) Pipe(transforms ...func(*SortedMap[K, T]) *SortedMap[K, T]) *SortedMap[K, T] {
+	result := sm
+
+	for _, transform := range transforms {
+		result = transform(result)
+	}
+
+	return result
+}
+
+// PipeE works like Pipe, but stops at the first transform returning an error and returns it with the result of the
+// previous transform.
+func (sm *SortedMap[K, T]) PipeE(transforms ...func(*SortedMap[K, T]) (*SortedMap[K, T], error)) (*SortedMap[K, T], error) {
+	result := sm
+
+	for _, transform := range transforms {
+		next, err := transform(result)
+		if err != nil {
+			return result, err
+		}
+
+		result = next
+	}
+
+	return result, nil
+}
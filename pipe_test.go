@@ -0,0 +1,62 @@
+package sortedmap_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func take(n int) func(*sortedmap.SortedMap[int, int]) *sortedmap.SortedMap[int, int] {
+	return func(sm *sortedmap.SortedMap[int, int]) *sortedmap.SortedMap[int, int] {
+		return sm.Take(n)
+	}
+}
+
+func drop(n int) func(*sortedmap.SortedMap[int, int]) *sortedmap.SortedMap[int, int] {
+	return func(sm *sortedmap.SortedMap[int, int]) *sortedmap.SortedMap[int, int] {
+		return sm.Drop(n)
+	}
+}
+
+func TestSortedMap_Pipe(t *testing.T) {
+	sm := sortedmap.New[int, int]()
+	for i := range 10 {
+		sm.Set(i, i)
+	}
+
+	assert.Equal(t, []int{2, 3, 4}, sm.Pipe(drop(2), take(3)).Keys())
+	assert.Same(t, sm, sm.Pipe())
+}
+
+func TestSortedMap_PipeE(t *testing.T) {
+	errEmpty := errors.New("empty")
+
+	sm := sortedmap.New[int, int]().
+		Set(1, 1).
+		Set(2, 2)
+
+	nonEmpty := func(sm *sortedmap.SortedMap[int, int]) (*sortedmap.SortedMap[int, int], error) {
+		if sm.Len() == 0 {
+			return nil, errEmpty
+		}
+
+		return sm, nil
+	}
+
+	dropE := func(n int) func(*sortedmap.SortedMap[int, int]) (*sortedmap.SortedMap[int, int], error) {
+		return func(sm *sortedmap.SortedMap[int, int]) (*sortedmap.SortedMap[int, int], error) {
+			return drop(n)(sm), nil
+		}
+	}
+
+	result, err := sm.PipeE(dropE(1), nonEmpty)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2}, result.Keys())
+
+	result, err = sm.PipeE(dropE(2), nonEmpty, dropE(1))
+	assert.ErrorIs(t, err, errEmpty)
+	assert.Equal(t, 0, result.Len())
+}
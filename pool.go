@@ -0,0 +1,27 @@
+package sortedmap
+
+import (
+	"sync"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Pool returns a pool creating empty maps, use GetFromPool and ReturnToPool to reuse maps kept in it.
+func Pool[K constraints.Ordered, T any]() *sync.Pool {
+	return &sync.Pool{
+		New: func() any {
+			return New[K, T]()
+		},
+	}
+}
+
+// GetFromPool returns an empty map from p, which must be created by Pool.
+func GetFromPool[K constraints.Ordered, T any](p *sync.Pool) *SortedMap[K, T] {
+	return p.Get().(*SortedMap[K, T])
+}
+
+// ReturnToPool resets sm and puts it back into p, sm must not be used afterwards. Observers and options of sm are kept,
+// so only maps obtained from GetFromPool should be returned.
+func ReturnToPool[K constraints.Ordered, T any](p *sync.Pool, sm *SortedMap[K, T]) {
+	p.Put(sm.Reset())
+}
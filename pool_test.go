@@ -0,0 +1,56 @@
+package sortedmap_test
+
+import (
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPool(t *testing.T) {
+	p := sortedmap.Pool[string, int]()
+
+	sm := sortedmap.GetFromPool[string, int](p)
+	assert.Equal(t, 0, sm.Len())
+
+	sm.Set("key1", 1)
+
+	sortedmap.ReturnToPool(p, sm)
+
+	assert.Equal(t, 0, sm.Len())
+	assert.Equal(t, 0, sortedmap.GetFromPool[string, int](p).Len())
+}
+
+func fill(sm *sortedmap.SortedMap[int, int]) {
+	for i := range 100 {
+		sm.Set(i, i)
+	}
+}
+
+func BenchmarkPool(b *testing.B) {
+	b.Run("new", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for range b.N {
+			for range 1000 {
+				fill(sortedmap.New[int, int]())
+			}
+		}
+	})
+
+	b.Run("pooled", func(b *testing.B) {
+		b.ReportAllocs()
+
+		p := sortedmap.Pool[int, int]()
+
+		for range b.N {
+			for range 1000 {
+				sm := sortedmap.GetFromPool[int, int](p)
+
+				fill(sm)
+
+				sortedmap.ReturnToPool(p, sm)
+			}
+		}
+	})
+}
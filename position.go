@@ -0,0 +1,107 @@
+package sortedmap
+
+import (
+	"errors"
+	"slices"
+)
+
+var (
+	ErrEmptyMap        = errors.New("map is empty")
+	ErrIndexOutOfRange = errors.New("index out of range")
+)
+
+// First returns the first entry of the map, which holds the largest key in a descending map.
+func (sm *SortedMap[K, T]) First() (K, T, error) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	key, value, ok := sm.entryAt(0)
+	if !ok {
+		return key, value, ErrEmptyMap
+	}
+
+	return key, value, nil
+}
+
+// Last returns the last entry of the map, which holds the smallest key in a descending map.
+func (sm *SortedMap[K, T]) Last() (K, T, error) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	key, value, ok := sm.entryAt(len(sm.sortedKeys) - 1)
+	if !ok {
+		return key, value, ErrEmptyMap
+	}
+
+	return key, value, nil
+}
+
+// GetAt returns the value at the 0-based index of the map.
+func (sm *SortedMap[K, T]) GetAt(index int) (T, error) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	_, value, ok := sm.entryAt(index)
+	if !ok {
+		return value, ErrIndexOutOfRange
+	}
+
+	return value, nil
+}
+
+// MustGetAt works like GetAt, but panics if index is out of range.
+func (sm *SortedMap[K, T]) MustGetAt(index int) T {
+	value, err := sm.GetAt(index)
+	if err != nil {
+		panic(err)
+	}
+
+	return value
+}
+
+// KeyAt returns the key at the 0-based index of the map.
+func (sm *SortedMap[K, T]) KeyAt(index int) (K, error) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	key, _, ok := sm.entryAt(index)
+	if !ok {
+		return key, ErrIndexOutOfRange
+	}
+
+	return key, nil
+}
+
+// DeleteAt deletes the entry at the 0-based index of the map, it does nothing if index is out of range.
+func (sm *SortedMap[K, T]) DeleteAt(index int) *SortedMap[K, T] {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	key, value, ok := sm.entryAt(index)
+	if !ok {
+		return sm
+	}
+
+	delete(sm.items, key)
+
+	sm.sortedKeys = slices.Delete(sm.sortedKeys, index, index+1)
+
+	sm.version.Add(1)
+
+	sm.notifyDelete(key, value)
+
+	return sm
+}
+
+// IndexOf returns the 0-based index of key in the map, so that KeyAt returns key for the same index.
+func (sm *SortedMap[K, T]) IndexOf(key K) (int, error) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	i := searchSorted(sm.sortedKeys, key, sm.direction)
+	if i >= len(sm.sortedKeys) || sm.sortedKeys[i] != key {
+		return -1, ErrKeyDoesNotExist
+	}
+
+	return i, nil
+}
@@ -0,0 +1,136 @@
+package sortedmap_test
+
+import (
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortedMap_First(t *testing.T) {
+	sm := sortedmap.New[string, int]().
+		Set("key2", 2).
+		Set("key3", 3).
+		Set("key1", 1)
+
+	key, value, err := sm.First()
+	require.NoError(t, err)
+	assert.Equal(t, "key1", key)
+	assert.Equal(t, 1, value)
+
+	key, value, err = sm.Last()
+	require.NoError(t, err)
+	assert.Equal(t, "key3", key)
+	assert.Equal(t, 3, value)
+
+	key, _, err = sm.Immutable().First()
+	require.NoError(t, err)
+	assert.Equal(t, "key1", key)
+
+	key, _, err = sortedmap.New(sortedmap.WithDescending[string, int]()).Set("key1", 1).Set("key2", 2).First()
+	require.NoError(t, err)
+	assert.Equal(t, "key2", key)
+}
+
+func TestSortedMap_First_Empty(t *testing.T) {
+	sm := sortedmap.New[string, int]()
+
+	key, value, err := sm.First()
+	assert.ErrorIs(t, err, sortedmap.ErrEmptyMap)
+	assert.Equal(t, "", key)
+	assert.Equal(t, 0, value)
+
+	_, _, err = sm.Last()
+	assert.ErrorIs(t, err, sortedmap.ErrEmptyMap)
+	assert.NotErrorIs(t, err, sortedmap.ErrKeyDoesNotExist)
+}
+
+func TestSortedMap_GetAt(t *testing.T) {
+	sm := sortedmap.New[string, int]().
+		Set("key2", 2).
+		Set("key3", 3).
+		Set("key1", 1)
+
+	tests := []struct {
+		name          string
+		index         int
+		expectedKey   string
+		expectedValue int
+		expectedErr   error
+	}{
+		{name: "first", index: 0, expectedKey: "key1", expectedValue: 1},
+		{name: "middle", index: 1, expectedKey: "key2", expectedValue: 2},
+		{name: "last", index: 2, expectedKey: "key3", expectedValue: 3},
+		{name: "negative", index: -1, expectedErr: sortedmap.ErrIndexOutOfRange},
+		{name: "after last", index: 3, expectedErr: sortedmap.ErrIndexOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := sm.GetAt(tt.index)
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedValue, value)
+
+			key, err := sm.KeyAt(tt.index)
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedKey, key)
+		})
+	}
+}
+
+func TestSortedMap_MustGetAt(t *testing.T) {
+	sm := sortedmap.New[string, int]().Set("key1", 1)
+
+	assert.Equal(t, 1, sm.MustGetAt(0))
+	assert.PanicsWithError(t, sortedmap.ErrIndexOutOfRange.Error(), func() {
+		sm.MustGetAt(1)
+	})
+}
+
+func TestSortedMap_DeleteAt(t *testing.T) {
+	sm := sortedmap.New[string, int]().
+		Set("key2", 2).
+		Set("key3", 3).
+		Set("key1", 1)
+
+	version := sm.Version()
+
+	sm.DeleteAt(-1).DeleteAt(3)
+	assert.Equal(t, []string{"key1", "key2", "key3"}, sm.Keys())
+	assert.Equal(t, version, sm.Version())
+
+	sm.DeleteAt(1)
+	assert.Equal(t, []string{"key1", "key3"}, sm.Keys())
+	assert.False(t, sm.Has("key2"))
+
+	sm.DeleteAt(0).DeleteAt(0)
+	assert.Equal(t, 0, sm.Len())
+}
+
+func TestSortedMap_IndexOf(t *testing.T) {
+	sm := sortedmap.New[string, int]().
+		Set("key2", 2).
+		Set("key3", 3).
+		Set("key1", 1)
+
+	for _, key := range sm.Keys() {
+		index, err := sm.IndexOf(key)
+		require.NoError(t, err)
+
+		actual, err := sm.KeyAt(index)
+		require.NoError(t, err)
+		assert.Equal(t, key, actual)
+	}
+
+	index, err := sm.IndexOf("key3")
+	require.NoError(t, err)
+	assert.Equal(t, 2, index)
+
+	index, err = sm.IndexOf("key0")
+	assert.ErrorIs(t, err, sortedmap.ErrKeyDoesNotExist)
+	assert.Equal(t, -1, index)
+
+	_, err = sm.IndexOf("key4")
+	assert.ErrorIs(t, err, sortedmap.ErrKeyDoesNotExist)
+}
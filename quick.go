@@ -0,0 +1,54 @@
+package sortedmap
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+)
+
+// randomValue returns a random value of t for boolean, numeric and string kinds, and the zero value of t otherwise.
+// testing/quick is not used, as importing it registers a command line flag in every program using this package.
+func randomValue(t reflect.Type, rng *rand.Rand, size int) reflect.Value {
+	v := reflect.New(t).Elem()
+
+	switch t.Kind() {
+	case reflect.Bool:
+		v.SetBool(rng.Intn(2) == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(rng.Uint64()) >> (64 - t.Bits()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(rng.Uint64() >> (64 - t.Bits()))
+	case reflect.Float32:
+		v.SetFloat(float64(rng.Float32()) * math.MaxFloat32 * float64(1-2*rng.Intn(2)))
+	case reflect.Float64:
+		v.SetFloat(rng.Float64() * math.MaxFloat64 * float64(1-2*rng.Intn(2)))
+	case reflect.String:
+		runes := make([]rune, rng.Intn(size+1))
+		for i := range runes {
+			runes[i] = rune(rng.Intn(0x10ffff))
+		}
+
+		v.SetString(string(runes))
+	}
+
+	return v
+}
+
+// Generate implements quick.Generator, it returns a map with up to size random entries. Values of types other than
+// booleans, numbers and strings are left zero. The receiver is ignored, so it can be called on a nil map.
+func (*SortedMap[K, T]) Generate(rng *rand.Rand, size int) reflect.Value {
+	keyType, valueType := reflect.TypeFor[K](), reflect.TypeFor[T]()
+
+	n := rng.Intn(size + 1)
+
+	sm := NewWithCapacity[K, T](n)
+
+	for range n {
+		key := randomValue(keyType, rng, size).Interface().(K)
+		value, _ := randomValue(valueType, rng, size).Interface().(T)
+
+		sm.Set(key, value)
+	}
+
+	return reflect.ValueOf(sm)
+}
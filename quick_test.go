@@ -0,0 +1,36 @@
+package sortedmap_test
+
+import (
+	"slices"
+	"testing"
+	"testing/quick"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortedMap_Generate(t *testing.T) {
+	keysSorted := func(sm *sortedmap.SortedMap[string, int]) bool {
+		return slices.IsSorted(sm.Keys()) && sm.Len() == len(sm.Keys())
+	}
+
+	assert.NoError(t, quick.Check(keysSorted, nil))
+
+	deleteRemoves := func(sm *sortedmap.SortedMap[int, float64], key int) bool {
+		return !sm.Delete(key).Has(key)
+	}
+
+	assert.NoError(t, quick.Check(deleteRemoves, nil))
+
+	setStores := func(sm *sortedmap.SortedMap[float64, string], key float64, value string) bool {
+		return sm.Set(key, value).MustGet(key) == value
+	}
+
+	assert.NoError(t, quick.Check(setStores, nil))
+
+	lenMatches := func(sm *sortedmap.SortedMap[int8, any]) bool {
+		return sm.Len() == len(sm.Values())
+	}
+
+	assert.NoError(t, quick.Check(lenMatches, nil))
+}
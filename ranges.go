@@ -0,0 +1,31 @@
+package sortedmap
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+// IntegerRanges groups the keys of the map into runs of consecutive integers and returns them as inclusive
+// [start, end] ranges in ascending order.
+func IntegerRanges[K constraints.Integer, T any](sm *SortedMap[K, T]) [][2]K {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	ranges := make([][2]K, 0)
+
+	for _, key := range sm.keysIn(ascending) {
+		if last := len(ranges) - 1; last >= 0 && key == ranges[last][1]+1 {
+			ranges[last][1] = key
+
+			continue
+		}
+
+		ranges = append(ranges, [2]K{key, key})
+	}
+
+	return ranges
+}
+
+// ContiguousRanges works like IntegerRanges for maps with int keys.
+func ContiguousRanges[T any](sm *SortedMap[int, T]) [][2]int {
+	return IntegerRanges(sm)
+}
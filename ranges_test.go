@@ -0,0 +1,28 @@
+package sortedmap_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContiguousRanges(t *testing.T) {
+	sm := sortedmap.New[int, string]()
+	for _, key := range []int{9, 1, 2, 3, 5, 6} {
+		sm.Set(key, "")
+	}
+
+	assert.Equal(t, [][2]int{{1, 3}, {5, 6}, {9, 9}}, sortedmap.ContiguousRanges(sm))
+	assert.Equal(t, [][2]int{}, sortedmap.ContiguousRanges(sortedmap.New[int, string]()))
+}
+
+func TestIntegerRanges(t *testing.T) {
+	sm := sortedmap.New(sortedmap.WithDescending[uint8, string]()).
+		Set(math.MaxUint8, "").
+		Set(math.MaxUint8-1, "").
+		Set(0, "")
+
+	assert.Equal(t, [][2]uint8{{0, 0}, {math.MaxUint8 - 1, math.MaxUint8}}, sortedmap.IntegerRanges(sm))
+}
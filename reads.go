@@ -0,0 +1,42 @@
+package sortedmap
+
+import (
+	"sync/atomic"
+)
+
+type readStats struct {
+	gets   atomic.Uint64
+	misses atomic.Uint64
+}
+
+func (sm *SortedMap[K, T]) countRead(found bool) {
+	stats := sm.reads.Load()
+	if stats == nil {
+		return
+	}
+
+	stats.gets.Add(1)
+
+	if !found {
+		stats.misses.Add(1)
+	}
+}
+
+// CountReads enables counting the calls of Get and MustGet, which is disabled by default to keep reads cheap. Calling
+// it again has no effect.
+func (sm *SortedMap[K, T]) CountReads() *SortedMap[K, T] {
+	sm.reads.CompareAndSwap(nil, &readStats{})
+
+	return sm
+}
+
+// ReadStats returns the number of Get and MustGet calls and the number of them for missing keys since CountReads was
+// called.
+func (sm *SortedMap[K, T]) ReadStats() (gets, misses uint64) {
+	stats := sm.reads.Load()
+	if stats == nil {
+		return 0, 0
+	}
+
+	return stats.gets.Load(), stats.misses.Load()
+}
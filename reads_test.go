@@ -0,0 +1,31 @@
+package sortedmap_test
+
+import (
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortedMap_ReadStats(t *testing.T) {
+	sm := sortedmap.New[string, int]().
+		Set("key1", 1)
+
+	_, _ = sm.Get("key1")
+
+	gets, misses := sm.ReadStats()
+	assert.Zero(t, gets)
+	assert.Zero(t, misses)
+
+	sm.CountReads()
+
+	_, _ = sm.Get("key1")
+	_, _ = sm.Get("nope")
+	_ = sm.MustGet("key1")
+
+	sm.CountReads()
+
+	gets, misses = sm.ReadStats()
+	assert.Equal(t, uint64(3), gets)
+	assert.Equal(t, uint64(1), misses)
+}
@@ -0,0 +1,88 @@
+package sortedmap
+
+import (
+	"errors"
+	"math/rand"
+)
+
+// sampleIndexes selects min(n, size) distinct indexes from [0, size) in random order using Floyd's algorithm.
+func sampleIndexes(size, n int, rng *rand.Rand) []int {
+	n = min(n, size)
+	if n <= 0 {
+		return []int{}
+	}
+
+	selected := make(map[int]struct{}, n)
+	indexes := make([]int, 0, n)
+
+	for j := size - n; j < size; j++ {
+		i := rng.Intn(j + 1)
+		if _, exists := selected[i]; exists {
+			i = j
+		}
+
+		selected[i] = struct{}{}
+		indexes = append(indexes, i)
+	}
+
+	rng.Shuffle(len(indexes), func(i, j int) {
+		indexes[i], indexes[j] = indexes[j], indexes[i]
+	})
+
+	return indexes
+}
+
+// Sample returns n distinct entries chosen uniformly at random in no particular order, or all the entries in random
+// order if n is larger than the size of the map.
+func (sm *SortedMap[K, T]) Sample(n int, rng *rand.Rand) []Entry[K, T] {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	indexes := sampleIndexes(len(sm.sortedKeys), n, rng)
+
+	entries := make([]Entry[K, T], 0, len(indexes))
+	for _, i := range indexes {
+		key := sm.sortedKeys[i]
+
+		entries = append(entries, Entry[K, T]{Key: key, Value: sm.items[key]})
+	}
+
+	return entries
+}
+
+// SampleKeys works like Sample, but only returns the keys.
+func (sm *SortedMap[K, T]) SampleKeys(n int, rng *rand.Rand) []K {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	indexes := sampleIndexes(len(sm.sortedKeys), n, rng)
+
+	keys := make([]K, 0, len(indexes))
+	for _, i := range indexes {
+		keys = append(keys, sm.sortedKeys[i])
+	}
+
+	return keys
+}
+
+var ErrInvalidStep = errors.New("step must be positive")
+
+// SubSample returns a map with the entries at the indexes 0, every, 2*every and so on.
+func (sm *SortedMap[K, T]) SubSample(every int) (*SortedMap[K, T], error) {
+	if every <= 0 {
+		return nil, ErrInvalidStep
+	}
+
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	result := sm.newLike((len(sm.sortedKeys) + every - 1) / every)
+
+	for i := 0; i < len(sm.sortedKeys); i += every {
+		key := sm.sortedKeys[i]
+
+		result.push(key, sm.items[key])
+	}
+
+	return result, nil
+}
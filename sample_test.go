@@ -0,0 +1,88 @@
+package sortedmap_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortedMap_Sample(t *testing.T) {
+	sm := sortedmap.New[int, int]()
+	for i := range 20 {
+		sm.Set(i, i*10)
+	}
+
+	rng := rand.New(rand.NewSource(42))
+
+	entries := sm.Sample(5, rng)
+	require.Len(t, entries, 5)
+
+	seen := make(map[int]struct{})
+	for _, entry := range entries {
+		assert.Equal(t, entry.Key*10, entry.Value)
+		assert.NotContains(t, seen, entry.Key)
+
+		seen[entry.Key] = struct{}{}
+	}
+
+	assert.Len(t, sm.Sample(100, rng), 20)
+	assert.Empty(t, sm.Sample(0, rng))
+	assert.Empty(t, sm.Sample(-1, rng))
+	assert.Empty(t, sortedmap.New[int, int]().Sample(3, rng))
+}
+
+func TestSortedMap_Sample_Uniform(t *testing.T) {
+	sm := sortedmap.New[int, int]()
+	for i := range 10 {
+		sm.Set(i, i)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	counts := make(map[int]int)
+
+	for range 10000 {
+		for _, key := range sm.SampleKeys(3, rng) {
+			counts[key]++
+		}
+	}
+
+	for key := range 10 {
+		assert.InDelta(t, 3000, counts[key], 300, key)
+	}
+}
+
+func TestSortedMap_SampleKeys(t *testing.T) {
+	sm := sortedmap.New[string, int]().
+		Set("key1", 1).
+		Set("key2", 2).
+		Set("key3", 3)
+
+	keys := sm.SampleKeys(5, rand.New(rand.NewSource(7)))
+	assert.ElementsMatch(t, []string{"key1", "key2", "key3"}, keys)
+}
+
+func TestSortedMap_SubSample(t *testing.T) {
+	sm := sortedmap.New[int, int]()
+	for i := range 10 {
+		sm.Set(i, i*10)
+	}
+
+	sampled, err := sm.SubSample(3)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 3, 6, 9}, sampled.Keys())
+	assert.Equal(t, []int{0, 30, 60, 90}, sampled.Values())
+
+	sampled, err = sm.SubSample(1)
+	require.NoError(t, err)
+	assert.Equal(t, sm.Keys(), sampled.Keys())
+
+	sampled, err = sm.SubSample(20)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0}, sampled.Keys())
+
+	_, err = sm.SubSample(0)
+	assert.ErrorIs(t, err, sortedmap.ErrInvalidStep)
+}
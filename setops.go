@@ -0,0 +1,206 @@
+package sortedmap
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+// mergeScan walks two key slices sorted in direction calling onlyA, onlyB or both for each key, nil callbacks are
+// skipped.
+func mergeScan[K constraints.Ordered](a, b []K, direction int8, onlyA, onlyB, both func(K)) {
+	call := func(f func(K), key K) {
+		if f != nil {
+			f(key)
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch c := compareKeys(a[i], b[j], direction); {
+		case c < 0:
+			call(onlyA, a[i])
+			i++
+		case c > 0:
+			call(onlyB, b[j])
+			j++
+		default:
+			call(both, a[i])
+			i++
+			j++
+		}
+	}
+
+	for ; i < len(a); i++ {
+		call(onlyA, a[i])
+	}
+
+	for ; j < len(b); j++ {
+		call(onlyB, b[j])
+	}
+}
+
+// SymmetricDifference returns the entries of keys present in exactly one of sm and other.
+func (sm *SortedMap[K, T]) SymmetricDifference(other *SortedMap[K, T]) *SortedMap[K, T] {
+	unlock := lockPair(sm, false, other, false)
+	defer unlock()
+
+	result := sm.newLike(0)
+
+	mergeScan(
+		sm.sortedKeys,
+		other.keysIn(sm.direction),
+		sm.direction,
+		func(key K) {
+			result.push(key, sm.items[key])
+		},
+		func(key K) {
+			result.push(key, other.items[key])
+		},
+		nil,
+	)
+
+	return result
+}
+
+// IntersectWith returns the keys present in both sm and other, with values combined using merge(smValue, otherValue).
+// If merge is nil, the values of sm are kept.
+func (sm *SortedMap[K, T]) IntersectWith(other *SortedMap[K, T], merge func(T, T) T) *SortedMap[K, T] {
+	unlock := lockPair(sm, false, other, false)
+	defer unlock()
+
+	return sm.intersectWith(other, merge)
+}
+
+func (sm *SortedMap[K, T]) intersectWith(other *SortedMap[K, T], merge func(T, T) T) *SortedMap[K, T] {
+	result := sm.newLike(0)
+
+	mergeScan(
+		sm.sortedKeys,
+		other.keysIn(sm.direction),
+		sm.direction,
+		nil,
+		nil,
+		func(key K) {
+			value := sm.items[key]
+
+			if merge != nil {
+				value = merge(value, other.items[key])
+			}
+
+			result.push(key, value)
+		},
+	)
+
+	return result
+}
+
+// UnionWith returns the keys present in either sm or other, values of keys present in both are combined using
+// merge(smValue, otherValue). If merge is nil, the values of other are kept.
+func (sm *SortedMap[K, T]) UnionWith(other *SortedMap[K, T], merge func(T, T) T) *SortedMap[K, T] {
+	unlock := lockPair(sm, false, other, false)
+	defer unlock()
+
+	return sm.unionWith(other, merge)
+}
+
+func (sm *SortedMap[K, T]) unionWith(other *SortedMap[K, T], merge func(T, T) T) *SortedMap[K, T] {
+	result := sm.newLike(max(len(sm.sortedKeys), len(other.sortedKeys)))
+
+	mergeScan(
+		sm.sortedKeys,
+		other.keysIn(sm.direction),
+		sm.direction,
+		func(key K) {
+			result.push(key, sm.items[key])
+		},
+		func(key K) {
+			result.push(key, other.items[key])
+		},
+		func(key K) {
+			value := other.items[key]
+
+			if merge != nil {
+				value = merge(sm.items[key], value)
+			}
+
+			result.push(key, value)
+		},
+	)
+
+	return result
+}
+
+// Merge returns a new map holding the entries of both sm and other, values of other win for keys present in both.
+func (sm *SortedMap[K, T]) Merge(other *SortedMap[K, T]) *SortedMap[K, T] {
+	return sm.UnionWith(other, nil)
+}
+
+// MergeInto stores the entries of other in sm, overwriting the values of keys present in both. Entries rejected by
+// the validators of sm or because sm is full are skipped.
+func (sm *SortedMap[K, T]) MergeInto(other *SortedMap[K, T]) *SortedMap[K, T] {
+	other.CopyTo(sm)
+
+	return sm
+}
+
+// Coalesce returns the entries of sm completed with the entries of other for keys missing from sm.
+func (sm *SortedMap[K, T]) Coalesce(other *SortedMap[K, T]) *SortedMap[K, T] {
+	unlock := lockPair(sm, false, other, false)
+	defer unlock()
+
+	return sm.unionWith(other, func(smValue, _ T) T {
+		return smValue
+	})
+}
+
+// Interleave returns the entries of sm and other alternating, each map contributing its entries in its own order and
+// the remaining entries of the larger map being appended at the end. The result is not sorted, so it is returned as a
+// slice rather than a map. Use UnionWith to merge the maps by key instead.
+func (sm *SortedMap[K, T]) Interleave(other *SortedMap[K, T]) []Entry[K, T] {
+	unlock := lockPair(sm, false, other, false)
+	defer unlock()
+
+	entries := make([]Entry[K, T], 0, len(sm.sortedKeys)+len(other.sortedKeys))
+
+	for i := 0; i < max(len(sm.sortedKeys), len(other.sortedKeys)); i++ {
+		if i < len(sm.sortedKeys) {
+			entries = append(entries, Entry[K, T]{Key: sm.sortedKeys[i], Value: sm.items[sm.sortedKeys[i]]})
+		}
+
+		if i < len(other.sortedKeys) {
+			entries = append(entries, Entry[K, T]{Key: other.sortedKeys[i], Value: other.items[other.sortedKeys[i]]})
+		}
+	}
+
+	return entries
+}
+
+// Union returns the entries of both a and b, values of b win for keys present in both.
+func Union[K constraints.Ordered, T any](a, b *SortedMap[K, T]) *SortedMap[K, T] {
+	return a.UnionWith(b, nil)
+}
+
+// Intersection returns the entries of keys present in both a and b, with values combined using resolve(aValue, bValue).
+func Intersection[K constraints.Ordered, T any](a, b *SortedMap[K, T], resolve func(T, T) T) *SortedMap[K, T] {
+	return a.IntersectWith(b, resolve)
+}
+
+// Difference returns the entries of a with keys missing from b.
+func Difference[K constraints.Ordered, T any](a, b *SortedMap[K, T]) *SortedMap[K, T] {
+	unlock := lockPair(a, false, b, false)
+	defer unlock()
+
+	result := a.newLike(0)
+
+	mergeScan(
+		a.sortedKeys,
+		b.keysIn(a.direction),
+		a.direction,
+		func(key K) {
+			result.push(key, a.items[key])
+		},
+		nil,
+		nil,
+	)
+
+	return result
+}
@@ -0,0 +1,176 @@
+package sortedmap_test
+
+import (
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortedMap_SymmetricDifference(t *testing.T) {
+	a := sortedmap.New[string, int]().
+		Set("key1", 1).
+		Set("key2", 2).
+		Set("key4", 4)
+
+	b := sortedmap.New[string, int]().
+		Set("key2", 20).
+		Set("key3", 30).
+		Set("key5", 50)
+
+	actual := a.SymmetricDifference(b)
+	assert.Equal(t, []string{"key1", "key3", "key4", "key5"}, actual.Keys())
+	assert.Equal(t, []int{1, 30, 4, 50}, actual.Values())
+
+	assert.Equal(t, 3, a.Len())
+	assert.Equal(t, 3, b.Len())
+
+	assert.Equal(t, 0, a.SymmetricDifference(a).Len())
+}
+
+func TestSortedMap_IntersectWith(t *testing.T) {
+	a := sortedmap.New[string, int]().
+		Set("key1", 1).
+		Set("key2", 2).
+		Set("key3", 3)
+
+	b := sortedmap.New[string, int]().
+		Set("key2", 20).
+		Set("key3", 1).
+		Set("key4", 40)
+
+	actual := a.IntersectWith(b, func(x, y int) int {
+		return min(x, y)
+	})
+	assert.Equal(t, []string{"key2", "key3"}, actual.Keys())
+	assert.Equal(t, []int{2, 1}, actual.Values())
+
+	actual = a.IntersectWith(b, nil)
+	assert.Equal(t, []int{2, 3}, actual.Values())
+
+	assert.Equal(t, 0, a.IntersectWith(sortedmap.New[string, int](), nil).Len())
+}
+
+func TestSortedMap_UnionWith(t *testing.T) {
+	a := sortedmap.New[string, int]().
+		Set("key1", 1).
+		Set("key2", 2)
+
+	b := sortedmap.New[string, int]().
+		Set("key2", 20).
+		Set("key3", 30)
+
+	actual := a.UnionWith(b, sum)
+	assert.Equal(t, []string{"key1", "key2", "key3"}, actual.Keys())
+	assert.Equal(t, []int{1, 22, 30}, actual.Values())
+
+	actual = a.UnionWith(b, nil)
+	assert.Equal(t, []int{1, 20, 30}, actual.Values())
+
+	assert.Equal(t, []int{1, 2}, a.Values())
+	assert.Equal(t, []int{20, 30}, b.Values())
+}
+
+func TestSortedMap_UnionWith_Slices(t *testing.T) {
+	a := sortedmap.New[string, []string]().
+		Set("key1", []string{"a"})
+
+	b := sortedmap.New[string, []string]().
+		Set("key1", []string{"b"}).
+		Set("key2", []string{"c"})
+
+	actual := a.UnionWith(b, func(x, y []string) []string {
+		return append(append([]string{}, x...), y...)
+	})
+	assert.Equal(t, [][]string{{"a", "b"}, {"c"}}, actual.Values())
+}
+
+func TestSortedMap_Coalesce(t *testing.T) {
+	defaults := sortedmap.New[string, string]().
+		Set("color", "blue").
+		Set("size", "m")
+
+	userConfig := sortedmap.New[string, string]().
+		Set("size", "xl").
+		Set("theme", "dark")
+
+	actual := userConfig.Coalesce(defaults)
+	assert.Equal(t, []string{"color", "size", "theme"}, actual.Keys())
+	assert.Equal(t, []string{"blue", "xl", "dark"}, actual.Values())
+
+	assert.Equal(t, 2, userConfig.Len())
+}
+
+func TestSortedMap_Interleave(t *testing.T) {
+	a := sortedmap.New[string, int]().
+		Set("a1", 1).
+		Set("a2", 2).
+		Set("a3", 3)
+
+	b := sortedmap.New[string, int]().
+		Set("a1", 10)
+
+	assert.Equal(t, []sortedmap.Entry[string, int]{
+		{Key: "a1", Value: 1},
+		{Key: "a1", Value: 10},
+		{Key: "a2", Value: 2},
+		{Key: "a3", Value: 3},
+	}, a.Interleave(b))
+
+	assert.Equal(t, []sortedmap.Entry[string, int]{
+		{Key: "a1", Value: 10},
+		{Key: "a1", Value: 1},
+		{Key: "a2", Value: 2},
+		{Key: "a3", Value: 3},
+	}, b.Interleave(a))
+
+	assert.Empty(t, sortedmap.New[string, int]().Interleave(sortedmap.New[string, int]()))
+}
+
+func TestSortedMap_Merge(t *testing.T) {
+	sm := sortedmap.New[string, int]().
+		Set("key1", 1).
+		Set("key2", 2)
+
+	other := sortedmap.New[string, int]().
+		Set("key2", 20).
+		Set("key3", 30)
+
+	merged := sm.Merge(other)
+
+	assert.Equal(t, []string{"key1", "key2", "key3"}, merged.Keys())
+	assert.Equal(t, []int{1, 20, 30}, merged.Values())
+	assert.Equal(t, []int{1, 2}, sm.Values())
+
+	sm.MergeInto(other)
+
+	assert.Equal(t, []string{"key1", "key2", "key3"}, sm.Keys())
+	assert.Equal(t, []int{1, 20, 30}, sm.Values())
+	assert.Equal(t, []int{20, 30}, other.Values())
+
+	assert.Equal(t, []int{1, 20, 30}, sm.MergeInto(sm).Values())
+}
+
+func TestUnion(t *testing.T) {
+	a := sortedmap.New[int, string]().Set(1, "a1").Set(2, "a2").Set(3, "a3")
+	b := sortedmap.New[int, string]().Set(2, "b2").Set(3, "b3").Set(4, "b4")
+
+	union := sortedmap.Union(a, b)
+	assert.Equal(t, []int{1, 2, 3, 4}, union.Keys())
+	assert.Equal(t, []string{"a1", "b2", "b3", "b4"}, union.Values())
+
+	intersection := sortedmap.Intersection(a, b, func(aValue, bValue string) string {
+		return aValue + bValue
+	})
+	assert.Equal(t, []int{2, 3}, intersection.Keys())
+	assert.Equal(t, []string{"a2b2", "a3b3"}, intersection.Values())
+
+	difference := sortedmap.Difference(a, b)
+	assert.Equal(t, []int{1}, difference.Keys())
+	assert.Equal(t, []string{"a1"}, difference.Values())
+
+	assert.Equal(t, 0, sortedmap.Difference(a, a).Len())
+
+	desc := sortedmap.New(sortedmap.WithDescending[int, string]()).Set(1, "d1").Set(4, "d4")
+	assert.Equal(t, []int{2, 3}, sortedmap.Difference(a, desc).Keys())
+}
@@ -0,0 +1,192 @@
+package sortedmap
+
+// subMap copies the entries found between the from and to indexes into a new map.
+func (sm *SortedMap[K, T]) subMap(from, to int) *SortedMap[K, T] {
+	result := sm.newLike(to - from)
+
+	for _, key := range sm.sortedKeys[from:to] {
+		result.push(key, sm.items[key])
+	}
+
+	return result
+}
+
+// TakeWhile returns the longest prefix of the map for which pred holds.
+func (sm *SortedMap[K, T]) TakeWhile(pred func(K, T) bool) *SortedMap[K, T] {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	i := 0
+	for ; i < len(sm.sortedKeys); i++ {
+		key := sm.sortedKeys[i]
+
+		if !pred(key, sm.items[key]) {
+			break
+		}
+	}
+
+	return sm.subMap(0, i)
+}
+
+// DropWhile returns the entries remaining after dropping the longest prefix of the map for which pred holds.
+func (sm *SortedMap[K, T]) DropWhile(pred func(K, T) bool) *SortedMap[K, T] {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	i := 0
+	for ; i < len(sm.sortedKeys); i++ {
+		key := sm.sortedKeys[i]
+
+		if !pred(key, sm.items[key]) {
+			break
+		}
+	}
+
+	return sm.subMap(i, len(sm.sortedKeys))
+}
+
+// Take returns the first n entries of the map. Negative values of n are treated as 0.
+func (sm *SortedMap[K, T]) Take(n int) *SortedMap[K, T] {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	return sm.subMap(0, min(max(n, 0), len(sm.sortedKeys)))
+}
+
+// Drop returns the entries remaining after skipping the first n entries of the map. Negative values of n are treated
+// as 0.
+func (sm *SortedMap[K, T]) Drop(n int) *SortedMap[K, T] {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	return sm.subMap(min(max(n, 0), len(sm.sortedKeys)), len(sm.sortedKeys))
+}
+
+// BalancedSplit splits the map into two independent maps at index Len()/2.
+func (sm *SortedMap[K, T]) BalancedSplit() (head, tail *SortedMap[K, T]) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	mid := len(sm.sortedKeys) / 2
+
+	return sm.subMap(0, mid), sm.subMap(mid, len(sm.sortedKeys))
+}
+
+// PartitionN splits the map into n independent maps of sizes differing by at most one, earlier chunks get the extra
+// entries. It returns nil if n is not positive.
+func (sm *SortedMap[K, T]) PartitionN(n int) []*SortedMap[K, T] {
+	if n <= 0 {
+		return nil
+	}
+
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	size, extra := len(sm.sortedKeys)/n, len(sm.sortedKeys)%n
+
+	chunks := make([]*SortedMap[K, T], 0, n)
+
+	from := 0
+	for i := range n {
+		to := from + size
+		if i < extra {
+			to++
+		}
+
+		chunks = append(chunks, sm.subMap(from, to))
+
+		from = to
+	}
+
+	return chunks
+}
+
+func (sm *SortedMap[K, T]) entries(from, to int) []Entry[K, T] {
+	entries := make([]Entry[K, T], 0, to-from)
+
+	for _, key := range sm.sortedKeys[from:to] {
+		entries = append(entries, Entry[K, T]{Key: key, Value: sm.items[key]})
+	}
+
+	return entries
+}
+
+// Page returns the entries of the 0-based page pageNum of size pageSize and whether there are more pages after it.
+func (sm *SortedMap[K, T]) Page(pageSize, pageNum int) ([]Entry[K, T], bool) {
+	if pageSize <= 0 || pageNum < 0 {
+		return []Entry[K, T]{}, false
+	}
+
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	from := min(pageSize*pageNum, len(sm.sortedKeys))
+	to := min(from+pageSize, len(sm.sortedKeys))
+
+	return sm.entries(from, to), to < len(sm.sortedKeys)
+}
+
+// PageAfter returns at most pageSize entries with keys strictly greater than afterKey.
+func (sm *SortedMap[K, T]) PageAfter(afterKey K, pageSize int) []Entry[K, T] {
+	if pageSize <= 0 {
+		return []Entry[K, T]{}
+	}
+
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	from := floorIndex(sm.sortedKeys, afterKey, sm.direction) + 1
+	to := min(from+pageSize, len(sm.sortedKeys))
+
+	return sm.entries(from, to)
+}
+
+// rangeIndexes returns the [from, to) indexes of the keys between lo and hi.
+func (sm *SortedMap[K, T]) rangeIndexes(lo, hi K, loInclusive, hiInclusive bool) (int, int) {
+	from := searchSorted(sm.sortedKeys, lo, sm.direction)
+	if !loInclusive && from < len(sm.sortedKeys) && sm.sortedKeys[from] == lo {
+		from++
+	}
+
+	to := searchSorted(sm.sortedKeys, hi, sm.direction)
+	if hiInclusive && to < len(sm.sortedKeys) && sm.sortedKeys[to] == hi {
+		to++
+	}
+
+	return from, max(from, to)
+}
+
+// ExistsInRange reports whether the map holds any key between lo and hi, without allocating.
+func (sm *SortedMap[K, T]) ExistsInRange(lo, hi K, loInclusive, hiInclusive bool) bool {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	from, to := sm.rangeIndexes(lo, hi, loInclusive, hiInclusive)
+
+	return from < to
+}
+
+// SubMap returns a new map holding the entries with keys between from and to, both inclusive. The result is empty if
+// from comes after to in the order of the map.
+func (sm *SortedMap[K, T]) SubMap(from, to K) *SortedMap[K, T] {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	return sm.subMap(sm.rangeIndexes(from, to, true, true))
+}
+
+// HeadMap returns a new map holding the entries with keys before to, exclusive.
+func (sm *SortedMap[K, T]) HeadMap(to K) *SortedMap[K, T] {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	return sm.subMap(0, searchSorted(sm.sortedKeys, to, sm.direction))
+}
+
+// TailMap returns a new map holding the entries with keys from from on, inclusive.
+func (sm *SortedMap[K, T]) TailMap(from K) *SortedMap[K, T] {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	return sm.subMap(searchSorted(sm.sortedKeys, from, sm.direction), len(sm.sortedKeys))
+}
@@ -0,0 +1,249 @@
+package sortedmap_test
+
+import (
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortedMap_TakeWhile(t *testing.T) {
+	sm := sortedmap.New[int, int]().
+		Set(1, 1).
+		Set(2, 2).
+		Set(3, 30).
+		Set(4, 4)
+
+	small := func(_, v int) bool {
+		return v < 10
+	}
+
+	actual := sm.TakeWhile(small)
+	assert.Equal(t, []int{1, 2}, actual.Keys())
+	assert.Equal(t, []int{1, 2}, actual.Values())
+
+	assert.Equal(t, 0, sortedmap.New[int, int]().TakeWhile(small).Len())
+	assert.Equal(t, 4, sm.Len())
+}
+
+func TestSortedMap_DropWhile(t *testing.T) {
+	sm := sortedmap.New[int, int]().
+		Set(1, 1).
+		Set(2, 2).
+		Set(3, 30).
+		Set(4, 4)
+
+	small := func(_, v int) bool {
+		return v < 10
+	}
+
+	actual := sm.DropWhile(small)
+	assert.Equal(t, []int{3, 4}, actual.Keys())
+	assert.Equal(t, []int{30, 4}, actual.Values())
+
+	actual = sm.DropWhile(func(_, _ int) bool {
+		return true
+	})
+	assert.Equal(t, 0, actual.Len())
+}
+
+func TestSortedMap_TakeAndDrop(t *testing.T) {
+	sm := sortedmap.New[string, int]().
+		Set("key3", 3).
+		Set("key1", 1).
+		Set("key2", 2)
+
+	tests := []struct {
+		name         string
+		n            int
+		expectedTake []string
+		expectedDrop []string
+	}{
+		{
+			name:         "negative",
+			n:            -1,
+			expectedTake: []string{},
+			expectedDrop: []string{"key1", "key2", "key3"},
+		},
+		{
+			name:         "zero",
+			n:            0,
+			expectedTake: []string{},
+			expectedDrop: []string{"key1", "key2", "key3"},
+		},
+		{
+			name:         "middle",
+			n:            2,
+			expectedTake: []string{"key1", "key2"},
+			expectedDrop: []string{"key3"},
+		},
+		{
+			name:         "more than length",
+			n:            5,
+			expectedTake: []string{"key1", "key2", "key3"},
+			expectedDrop: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedTake, sm.Take(tt.n).Keys())
+			assert.Equal(t, tt.expectedDrop, sm.Drop(tt.n).Keys())
+		})
+	}
+}
+
+func TestSortedMap_Take_Independent(t *testing.T) {
+	sm := sortedmap.New[string, int]().
+		Set("key1", 1).
+		Set("key2", 2)
+
+	head := sm.Take(5)
+	head.Set("key0", 0).Delete("key1")
+
+	assert.Equal(t, []string{"key1", "key2"}, sm.Keys())
+	assert.Equal(t, []string{"key0", "key2"}, head.Keys())
+}
+
+func TestSortedMap_BalancedSplit(t *testing.T) {
+	sm := sortedmap.New[int, int]()
+	for i := range 5 {
+		sm.Set(i, i*10)
+	}
+
+	head, tail := sm.BalancedSplit()
+	assert.Equal(t, []int{0, 1}, head.Keys())
+	assert.Equal(t, []int{2, 3, 4}, tail.Keys())
+	assert.Equal(t, []int{20, 30, 40}, tail.Values())
+
+	head, tail = sortedmap.New[int, int]().BalancedSplit()
+	assert.Equal(t, 0, head.Len())
+	assert.Equal(t, 0, tail.Len())
+}
+
+func TestSortedMap_PartitionN(t *testing.T) {
+	sm := sortedmap.New[int, int]()
+	for i := range 7 {
+		sm.Set(i, i)
+	}
+
+	chunks := sm.PartitionN(3)
+	require.Len(t, chunks, 3)
+	assert.Equal(t, []int{0, 1, 2}, chunks[0].Keys())
+	assert.Equal(t, []int{3, 4}, chunks[1].Keys())
+	assert.Equal(t, []int{5, 6}, chunks[2].Keys())
+
+	chunks = sm.PartitionN(10)
+	require.Len(t, chunks, 10)
+	assert.Equal(t, 1, chunks[6].Len())
+	assert.Equal(t, 0, chunks[7].Len())
+
+	assert.Nil(t, sm.PartitionN(0))
+}
+
+func TestSortedMap_Page(t *testing.T) {
+	sm := sortedmap.New[int, int]()
+	for i := range 40 {
+		sm.Set(i, i*10)
+	}
+
+	entries, hasMore := sm.Page(10, 2)
+	require.Len(t, entries, 10)
+	assert.True(t, hasMore)
+	assert.Equal(t, sortedmap.Entry[int, int]{Key: 20, Value: 200}, entries[0])
+	assert.Equal(t, 29, entries[9].Key)
+
+	entries, hasMore = sm.Page(10, 3)
+	require.Len(t, entries, 10)
+	assert.False(t, hasMore)
+	assert.Equal(t, 30, entries[0].Key)
+
+	entries, hasMore = sm.Page(10, 4)
+	assert.Empty(t, entries)
+	assert.False(t, hasMore)
+
+	entries, hasMore = sm.Page(15, 2)
+	assert.Len(t, entries, 10)
+	assert.False(t, hasMore)
+
+	entries, hasMore = sm.Page(0, 1)
+	assert.Empty(t, entries)
+	assert.False(t, hasMore)
+}
+
+func TestSortedMap_PageAfter(t *testing.T) {
+	sm := sortedmap.New[string, int]().
+		Set("a", 1).
+		Set("b", 2).
+		Set("c", 3).
+		Set("d", 4)
+
+	assert.Equal(t, []sortedmap.Entry[string, int]{{Key: "b", Value: 2}, {Key: "c", Value: 3}}, sm.PageAfter("a", 2))
+	assert.Equal(t, []sortedmap.Entry[string, int]{{Key: "c", Value: 3}, {Key: "d", Value: 4}}, sm.PageAfter("bb", 5))
+	assert.Equal(t, []sortedmap.Entry[string, int]{{Key: "a", Value: 1}}, sm.PageAfter("", 1))
+	assert.Empty(t, sm.PageAfter("d", 2))
+	assert.Empty(t, sm.PageAfter("a", 0))
+}
+
+func TestSortedMap_ExistsInRange(t *testing.T) {
+	sm := sortedmap.New[int, string]().
+		Set(10, "").
+		Set(20, "").
+		Set(30, "")
+
+	tests := []struct {
+		name        string
+		lo, hi      int
+		loInclusive bool
+		hiInclusive bool
+		expected    bool
+	}{
+		{name: "inside", lo: 15, hi: 25, expected: true},
+		{name: "gap", lo: 11, hi: 19, loInclusive: true, hiInclusive: true, expected: false},
+		{name: "inclusive bounds", lo: 20, hi: 20, loInclusive: true, hiInclusive: true, expected: true},
+		{name: "exclusive lo", lo: 20, hi: 21, expected: false},
+		{name: "exclusive hi", lo: 19, hi: 20, loInclusive: true, expected: false},
+		{name: "inclusive hi", lo: 19, hi: 20, hiInclusive: true, expected: true},
+		{name: "inverted", lo: 30, hi: 10, loInclusive: true, hiInclusive: true, expected: false},
+		{name: "after last", lo: 31, hi: 100, loInclusive: true, hiInclusive: true, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, sm.ExistsInRange(tt.lo, tt.hi, tt.loInclusive, tt.hiInclusive))
+		})
+	}
+}
+
+func TestSortedMap_SubMap(t *testing.T) {
+	sm := sortedmap.New[int, string]().
+		Set(1, "one").
+		Set(2, "two").
+		Set(3, "three").
+		Set(4, "four")
+
+	tests := []struct {
+		name         string
+		actual       *sortedmap.SortedMap[int, string]
+		expectedKeys []int
+	}{
+		{name: "sub map of present keys", actual: sm.SubMap(2, 3), expectedKeys: []int{2, 3}},
+		{name: "sub map of missing keys", actual: sm.SubMap(0, 10), expectedKeys: []int{1, 2, 3, 4}},
+		{name: "sub map of reversed range", actual: sm.SubMap(3, 2), expectedKeys: []int{}},
+		{name: "head map", actual: sm.HeadMap(3), expectedKeys: []int{1, 2}},
+		{name: "head map before first key", actual: sm.HeadMap(1), expectedKeys: []int{}},
+		{name: "tail map", actual: sm.TailMap(3), expectedKeys: []int{3, 4}},
+		{name: "tail map after last key", actual: sm.TailMap(5), expectedKeys: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedKeys, tt.actual.Keys())
+		})
+	}
+
+	sub := sm.SubMap(1, 2).Set(5, "five").Delete(1)
+	assert.Equal(t, []int{2, 5}, sub.Keys())
+	assert.Equal(t, []int{1, 2, 3, 4}, sm.Keys())
+}
@@ -1,21 +1,52 @@
 package sortedmap
 
 import (
+	"cmp"
 	"errors"
+	"fmt"
 	"iter"
+	"maps"
+	"runtime"
+	"slices"
 	"sort"
 	"sync"
+	"sync/atomic"
+	"unsafe"
 
 	"golang.org/x/exp/constraints"
 )
 
-func insertSorted[K constraints.Ordered](slice []K, value K) []K {
-	i := sort.Search(
+const (
+	ascending  int8 = 1
+	descending int8 = -1
+)
+
+// compareKeys compares a and b in the order given by direction, which is ascending or descending.
+func compareKeys[K constraints.Ordered](a, b K, direction int8) int {
+	return int(direction) * cmp.Compare(a, b)
+}
+
+func searchSorted[K constraints.Ordered](slice []K, value K, direction int8) int {
+	return sort.Search(
 		len(slice),
 		func(i int) bool {
-			return slice[i] >= value
+			return compareKeys(slice[i], value, direction) >= 0
 		},
 	)
+}
+
+// floorIndex returns the index of the last element at or before value in direction, or -1 if there is no such element.
+func floorIndex[K constraints.Ordered](slice []K, value K, direction int8) int {
+	i := searchSorted(slice, value, direction)
+	if i < len(slice) && slice[i] == value {
+		return i
+	}
+
+	return i - 1
+}
+
+func insertSorted[K constraints.Ordered](slice []K, value K, direction int8) []K {
+	i := searchSorted(slice, value, direction)
 
 	slice = append(slice, value)
 	copy(slice[i+1:], slice[i:])
@@ -24,13 +55,8 @@ func insertSorted[K constraints.Ordered](slice []K, value K) []K {
 	return slice
 }
 
-func deleteSorted[K constraints.Ordered](slice []K, value K) []K {
-	i := sort.Search(
-		len(slice),
-		func(i int) bool {
-			return slice[i] >= value
-		},
-	)
+func deleteSorted[K constraints.Ordered](slice []K, value K, direction int8) []K {
+	i := searchSorted(slice, value, direction)
 
 	if i < len(slice) && slice[i] == value {
 		copy(slice[i:], slice[i+1:])
@@ -41,26 +67,55 @@ func deleteSorted[K constraints.Ordered](slice []K, value K) []K {
 	return slice
 }
 
+type Entry[K constraints.Ordered, T any] struct {
+	Key   K
+	Value T
+}
+
 type SortedMap[K constraints.Ordered, T any] struct {
-	mu         *sync.RWMutex
-	items      map[K]T
-	sortedKeys []K
+	mu            RWLocker
+	items         map[K]T
+	sortedKeys    []K
+	validators    []func(K, T) error
+	maxSize       int
+	policy        EvictionPolicy
+	onEvict       func(K, T)
+	version       atomic.Uint64
+	observers     map[string]Observer[K, T]
+	observerNames []string
+	direction     int8
+	waiters       map[K][]chan struct{}
+	reads         atomic.Pointer[readStats]
 }
 
-func New[K constraints.Ordered, T any]() *SortedMap[K, T] {
-	return &SortedMap[K, T]{
+func New[K constraints.Ordered, T any](opts ...Option[K, T]) *SortedMap[K, T] {
+	sm := &SortedMap[K, T]{
 		mu:         &sync.RWMutex{},
 		items:      make(map[K]T),
 		sortedKeys: make([]K, 0),
+		direction:  ascending,
 	}
+
+	for _, opt := range opts {
+		opt(sm)
+	}
+
+	return sm
 }
 
-func NewWithCapacity[K constraints.Ordered, T any](capacity int) *SortedMap[K, T] {
-	return &SortedMap[K, T]{
+func NewWithCapacity[K constraints.Ordered, T any](capacity int, opts ...Option[K, T]) *SortedMap[K, T] {
+	sm := &SortedMap[K, T]{
 		mu:         &sync.RWMutex{},
 		items:      make(map[K]T, capacity),
 		sortedKeys: make([]K, 0, capacity),
+		direction:  ascending,
 	}
+
+	for _, opt := range opts {
+		opt(sm)
+	}
+
+	return sm
 }
 
 func NewFrom[K constraints.Ordered, T any](key K, value T) *SortedMap[K, T] {
@@ -73,6 +128,124 @@ func NewFrom[K constraints.Ordered, T any](key K, value T) *SortedMap[K, T] {
 		mu:         &sync.RWMutex{},
 		items:      items,
 		sortedKeys: sortedKeys,
+		direction:  ascending,
+	}
+}
+
+// NewFromEntries creates a map from entries, the last value wins for duplicate keys. The entries slice is sorted in
+// place.
+func NewFromEntries[K constraints.Ordered, T any](entries []Entry[K, T]) *SortedMap[K, T] {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Key < entries[j].Key
+	})
+
+	sm := NewWithCapacity[K, T](len(entries))
+
+	for i, entry := range entries {
+		if i+1 < len(entries) && entries[i+1].Key == entry.Key {
+			continue
+		}
+
+		sm.push(entry.Key, entry.Value)
+	}
+
+	return sm
+}
+
+// NewFromMap creates a map holding the entries of m, sorting the keys only once.
+func NewFromMap[K constraints.Ordered, T any](m map[K]T) *SortedMap[K, T] {
+	sm := NewWithCapacity[K, T](len(m))
+
+	for _, key := range slices.Sorted(maps.Keys(m)) {
+		sm.push(key, m[key])
+	}
+
+	return sm
+}
+
+// newLike returns an empty map which can hold entries of sm in the same order.
+func (sm *SortedMap[K, T]) newLike(capacity int) *SortedMap[K, T] {
+	result := NewWithCapacity[K, T](capacity)
+	result.direction = sm.direction
+
+	return result
+}
+
+// keysIn returns the keys of sm in the given direction, reversing a copy of them if needed.
+func (sm *SortedMap[K, T]) keysIn(direction int8) []K {
+	if direction == sm.direction {
+		return sm.sortedKeys
+	}
+
+	keys := slices.Clone(sm.sortedKeys)
+	slices.Reverse(keys)
+
+	return keys
+}
+
+// push appends an entry without searching for its position, keys must be pushed in order.
+func (sm *SortedMap[K, T]) push(key K, value T) {
+	sm.sortedKeys = append(sm.sortedKeys, key)
+	sm.items[key] = value
+}
+
+// snapshot copies the keys and values found between the from and to indexes.
+func (sm *SortedMap[K, T]) snapshot(from, to int) ([]K, []T) {
+	keys := make([]K, to-from)
+	copy(keys, sm.sortedKeys[from:to])
+
+	values := make([]T, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, sm.items[key])
+	}
+
+	return keys, values
+}
+
+// entryAt returns the entry at index i, or false if i is out of range.
+func (sm *SortedMap[K, T]) entryAt(i int) (K, T, bool) {
+	if i < 0 || i >= len(sm.sortedKeys) {
+		var (
+			zeroK K
+			zeroT T
+		)
+
+		return zeroK, zeroT, false
+	}
+
+	key := sm.sortedKeys[i]
+
+	return key, sm.items[key], true
+}
+
+func (sm *SortedMap[K, T]) lock(write bool) func() {
+	if write {
+		sm.mu.Lock()
+
+		return sm.mu.Unlock
+	}
+
+	sm.mu.RLock()
+
+	return sm.mu.RUnlock
+}
+
+// lockPair locks two maps in pointer-address order to avoid deadlocks, the returned function releases both locks.
+func lockPair[K constraints.Ordered, T any](a *SortedMap[K, T], aWrite bool, b *SortedMap[K, T], bWrite bool) func() {
+	if a == b {
+		return a.lock(aWrite || bWrite)
+	}
+
+	if uintptr(unsafe.Pointer(b)) < uintptr(unsafe.Pointer(a)) {
+		a, aWrite, b, bWrite = b, bWrite, a, aWrite
+	}
+
+	unlockA := a.lock(aWrite)
+	unlockB := b.lock(bWrite)
+
+	return func() {
+		unlockB()
+		unlockA()
 	}
 }
 
@@ -82,26 +255,187 @@ func (sm *SortedMap[K, T]) has(key K) bool {
 	return exists
 }
 
+func (sm *SortedMap[K, T]) validate(key K, value T) error {
+	for _, validator := range sm.validators {
+		if err := validator(key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (sm *SortedMap[K, T]) set(key K, value T) error {
+	if err := sm.validate(key, value); err != nil {
+		return err
+	}
+
+	old, exists := sm.items[key]
+	if !exists {
+		if sm.maxSize > 0 && len(sm.sortedKeys) >= sm.maxSize {
+			if err := sm.evict(); err != nil {
+				return err
+			}
+		}
+
+		sm.sortedKeys = insertSorted(sm.sortedKeys, key, sm.direction)
+	}
+
+	sm.items[key] = value
+
+	sm.version.Add(1)
+
+	sm.notifySet(key, old, exists, value)
+
+	sm.wake(key)
+
+	return nil
+}
+
+// evict removes one entry according to the eviction policy of the map.
+func (sm *SortedMap[K, T]) evict() error {
+	first, last := sm.sortedKeys[0], sm.sortedKeys[len(sm.sortedKeys)-1]
+	if sm.direction == descending {
+		first, last = last, first
+	}
+
+	var key K
+
+	switch sm.policy {
+	case EvictSmallest:
+		key = first
+	case EvictLargest:
+		key = last
+	default:
+		return ErrMapFull
+	}
+
+	value := sm.items[key]
+
+	sm.delete(key)
+
+	if sm.onEvict != nil {
+		sm.onEvict(key, value)
+	}
+
+	return nil
+}
+
+// Set stores value for key. Pairs rejected by the validators of the map or because the map is full are ignored, use
+// SetE to get the error.
 func (sm *SortedMap[K, T]) Set(key K, value T) *SortedMap[K, T] {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
-	if !sm.has(key) {
-		sm.sortedKeys = insertSorted(sm.sortedKeys, key)
+	_ = sm.set(key, value)
+
+	return sm
+}
+
+// SetE works like Set, but returns the error of the first failing validator, or ErrMapFull, leaving the map unchanged
+// in that case.
+func (sm *SortedMap[K, T]) SetE(key K, value T) (*SortedMap[K, T], error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	return sm, sm.set(key, value)
+}
+
+// SetDefault stores value if key is not yet present. It returns the value stored for key after the call and whether
+// the insertion happened.
+func (sm *SortedMap[K, T]) SetDefault(key K, value T) (T, bool) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if existing, exists := sm.items[key]; exists {
+		return existing, false
 	}
 
-	sm.items[key] = value
+	if err := sm.set(key, value); err != nil {
+		var zero T
+
+		return zero, false
+	}
+
+	return value, true
+}
+
+// UpdateWithPrevious calls f with the value of key and whether it exists under the write lock. If f returns keep as
+// false, key is deleted, otherwise the new value of f is stored. It returns the previous value, the value stored for
+// key after the call and whether key existed before. If the new value is rejected, the previous one is kept.
+func (sm *SortedMap[K, T]) UpdateWithPrevious(key K, f func(prev T, exists bool) (T, bool)) (T, T, bool) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	prev, exists := sm.items[key]
+
+	value, keep := f(prev, exists)
+	if !keep {
+		sm.delete(key)
+
+		var zero T
+
+		return prev, zero, exists
+	}
+
+	if err := sm.set(key, value); err != nil {
+		return prev, prev, exists
+	}
+
+	return prev, value, exists
+}
+
+func (sm *SortedMap[K, T]) accumulate(key K, values []T, merge func(T, T) T) {
+	if len(values) == 0 {
+		return
+	}
+
+	value := sm.items[key]
+	for _, v := range values {
+		value = merge(value, v)
+	}
+
+	_ = sm.set(key, value)
+}
+
+// Accumulate folds values into the value of key from left to right using merge under a single write lock, missing
+// keys starting from the zero value. Results rejected by the validators of the map are ignored.
+func (sm *SortedMap[K, T]) Accumulate(key K, values []T, merge func(T, T) T) *SortedMap[K, T] {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	sm.accumulate(key, values, merge)
 
 	return sm
 }
 
-var ErrKeyDoesNotExist = errors.New("key does not exist")
+// AccumulateAll works like Accumulate for multiple keys, which are processed in sorted order.
+func (sm *SortedMap[K, T]) AccumulateAll(entries map[K][]T, merge func(T, T) T) *SortedMap[K, T] {
+	keys := slices.Sorted(maps.Keys(entries))
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	for _, key := range keys {
+		sm.accumulate(key, entries[key], merge)
+	}
+
+	return sm
+}
+
+var (
+	ErrKeyDoesNotExist = errors.New("key does not exist")
+	ErrMapFull         = errors.New("map is full")
+)
 
 func (sm *SortedMap[K, T]) Get(key K) (T, error) {
 	sm.mu.RLock()
 	defer sm.mu.RUnlock()
 
 	value, exists := sm.items[key]
+
+	sm.countRead(exists)
+
 	if !exists {
 		return value, ErrKeyDoesNotExist
 	}
@@ -109,11 +443,30 @@ func (sm *SortedMap[K, T]) Get(key K) (T, error) {
 	return value, nil
 }
 
+// VersionedGet works like Get, but also returns the version of the map the value was read at, even if key is missing.
+func (sm *SortedMap[K, T]) VersionedGet(key K) (T, uint64, error) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	value, exists := sm.items[key]
+
+	sm.countRead(exists)
+
+	if !exists {
+		return value, sm.version.Load(), ErrKeyDoesNotExist
+	}
+
+	return value, sm.version.Load(), nil
+}
+
 func (sm *SortedMap[K, T]) MustGet(key K) T {
 	sm.mu.RLock()
 	defer sm.mu.RUnlock()
 
 	value, exists := sm.items[key]
+
+	sm.countRead(exists)
+
 	if !exists {
 		panic(ErrKeyDoesNotExist)
 	}
@@ -121,6 +474,45 @@ func (sm *SortedMap[K, T]) MustGet(key K) T {
 	return value
 }
 
+// GetMultiple returns the values of keys under a single read lock, values[i] being the zero value if keys[i] is
+// missing. The missing keys are also returned in the order they were given.
+func (sm *SortedMap[K, T]) GetMultiple(keys []K) ([]T, []K) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	values := make([]T, len(keys))
+	missing := make([]K, 0)
+
+	for i, key := range keys {
+		value, exists := sm.items[key]
+
+		sm.countRead(exists)
+
+		if !exists {
+			missing = append(missing, key)
+
+			continue
+		}
+
+		values[i] = value
+	}
+
+	return values, missing
+}
+
+// Prefetch reads the values of keys to warm up the CPU caches before the values are used, it has no other effect.
+func (sm *SortedMap[K, T]) Prefetch(keys []K) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	for _, key := range keys {
+		value, exists := sm.items[key]
+		if exists {
+			runtime.KeepAlive(value)
+		}
+	}
+}
+
 func (sm *SortedMap[K, T]) Len() int {
 	sm.mu.RLock()
 	defer sm.mu.RUnlock()
@@ -132,6 +524,52 @@ func (sm *SortedMap[K, T]) Len() int {
 	return len(sm.items)
 }
 
+// Reserve grows the capacity of the map so that additional new entries can be stored without reallocating. The
+// entries are copied while the write lock is held, concurrent callers wait and see the content of the map unchanged.
+func (sm *SortedMap[K, T]) Reserve(additional int) *SortedMap[K, T] {
+	if additional <= 0 {
+		return sm
+	}
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	size := len(sm.sortedKeys) + additional
+	if cap(sm.sortedKeys) >= size {
+		return sm
+	}
+
+	sm.sortedKeys = append(make([]K, 0, size), sm.sortedKeys...)
+
+	items := make(map[K]T, size)
+	for key, value := range sm.items {
+		items[key] = value
+	}
+
+	sm.items = items
+
+	return sm
+}
+
+// Defragment rebuilds the internal storage of the map with the exact capacity needed for its entries, releasing the
+// memory left behind by deleted entries. It copies all entries while holding the write lock.
+func (sm *SortedMap[K, T]) Defragment() *SortedMap[K, T] {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	items := make(map[K]T, len(sm.sortedKeys))
+	for _, key := range sm.sortedKeys {
+		items[key] = sm.items[key]
+	}
+
+	sortedKeys := make([]K, len(sm.sortedKeys))
+	copy(sortedKeys, sm.sortedKeys)
+
+	sm.items, sm.sortedKeys = items, sortedKeys
+
+	return sm
+}
+
 func (sm *SortedMap[K, T]) Has(key K) bool {
 	sm.mu.RLock()
 	defer sm.mu.RUnlock()
@@ -165,43 +603,260 @@ func (sm *SortedMap[K, T]) HasAny(keys ...K) bool {
 	return false
 }
 
+func (sm *SortedMap[K, T]) delete(key K) bool {
+	value, exists := sm.items[key]
+	if !exists {
+		return false
+	}
+
+	delete(sm.items, key)
+
+	sm.sortedKeys = deleteSorted(sm.sortedKeys, key, sm.direction)
+
+	sm.version.Add(1)
+
+	sm.notifyDelete(key, value)
+
+	return true
+}
+
 func (sm *SortedMap[K, T]) Delete(keys ...K) *SortedMap[K, T] {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
 	for _, key := range keys {
-		if !sm.has(key) {
+		sm.delete(key)
+	}
+
+	return sm
+}
+
+// DeleteE deletes key and returns ErrKeyDoesNotExist if it is not present.
+func (sm *SortedMap[K, T]) DeleteE(key K) (*SortedMap[K, T], error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if !sm.delete(key) {
+		return sm, ErrKeyDoesNotExist
+	}
+
+	return sm, nil
+}
+
+// MustDelete deletes key and panics if it is not present.
+func (sm *SortedMap[K, T]) MustDelete(key K) *SortedMap[K, T] {
+	if _, err := sm.DeleteE(key); err != nil {
+		panic(fmt.Errorf("%w: %v", err, key))
+	}
+
+	return sm
+}
+
+// BatchDelete deletes keys in a single sweep over the map, which is faster than Delete for a large number of keys. The
+// keys slice is sorted in place.
+func (sm *SortedMap[K, T]) BatchDelete(keys []K) *SortedMap[K, T] {
+	slices.SortFunc(keys, func(a, b K) int {
+		return compareKeys(a, b, sm.direction)
+	})
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	i := 0
+
+	sm.retain(func(key K, _ T) bool {
+		for i < len(keys) && compareKeys(keys[i], key, sm.direction) < 0 {
+			i++
+		}
+
+		return i >= len(keys) || keys[i] != key
+	})
+
+	return sm
+}
+
+// retain removes all entries for which keep returns false in a single pass.
+func (sm *SortedMap[K, T]) retain(keep func(K, T) bool) {
+	kept := sm.sortedKeys[:0]
+
+	for _, key := range sm.sortedKeys {
+		value := sm.items[key]
+
+		if keep(key, value) {
+			kept = append(kept, key)
+
 			continue
 		}
 
 		delete(sm.items, key)
 
-		sm.sortedKeys = deleteSorted(sm.sortedKeys, key)
+		sm.version.Add(1)
+
+		sm.notifyDelete(key, value)
 	}
 
+	clear(sm.sortedKeys[len(kept):])
+
+	sm.sortedKeys = kept
+}
+
+// Retain removes all entries except the ones with the given keys.
+func (sm *SortedMap[K, T]) Retain(keys ...K) *SortedMap[K, T] {
+	lookup := make(map[K]struct{}, len(keys))
+	for _, key := range keys {
+		lookup[key] = struct{}{}
+	}
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	sm.retain(func(key K, _ T) bool {
+		_, exists := lookup[key]
+
+		return exists
+	})
+
 	return sm
 }
 
+// RetainIf removes all entries for which predicate returns false.
+func (sm *SortedMap[K, T]) RetainIf(predicate func(K, T) bool) *SortedMap[K, T] {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	sm.retain(predicate)
+
+	return sm
+}
+
+// filter copies the entries for which predicate returns keep into a new map.
+func (sm *SortedMap[K, T]) filter(predicate func(K, T) bool, keep bool) *SortedMap[K, T] {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	result := sm.newLike(0)
+
+	for _, key := range sm.sortedKeys {
+		if value := sm.items[key]; predicate(key, value) == keep {
+			result.push(key, value)
+		}
+	}
+
+	return result
+}
+
+// Filter returns a new map holding the entries for which predicate returns true, sm is not modified.
+func (sm *SortedMap[K, T]) Filter(predicate func(K, T) bool) *SortedMap[K, T] {
+	return sm.filter(predicate, true)
+}
+
+// Reject returns a new map holding the entries for which predicate returns false, sm is not modified.
+func (sm *SortedMap[K, T]) Reject(predicate func(K, T) bool) *SortedMap[K, T] {
+	return sm.filter(predicate, false)
+}
+
+// reset removes all entries while keeping the allocated memory of the map.
+func (sm *SortedMap[K, T]) reset() {
+	if len(sm.sortedKeys) == 0 {
+		return
+	}
+
+	for _, key := range sm.sortedKeys {
+		sm.notifyDelete(key, sm.items[key])
+	}
+
+	clear(sm.items)
+	clear(sm.sortedKeys)
+
+	sm.sortedKeys = sm.sortedKeys[:0]
+
+	sm.version.Add(1)
+}
+
+// replace replaces the content of the map with entries, the map is left unchanged if any of them is rejected.
+func (sm *SortedMap[K, T]) replace(entries []Entry[K, T]) error {
+	items, sortedKeys, observers := sm.items, sm.sortedKeys, sm.observers
+
+	sm.items = make(map[K]T, len(entries))
+	sm.sortedKeys = make([]K, 0, len(entries))
+	sm.observers = nil
+
+	for _, entry := range entries {
+		if err := sm.set(entry.Key, entry.Value); err != nil {
+			sm.items, sm.sortedKeys, sm.observers = items, sortedKeys, observers
+
+			return err
+		}
+	}
+
+	sm.observers = observers
+
+	sm.version.Add(1)
+
+	sm.notifyReplaced(items, sortedKeys)
+
+	return nil
+}
+
+// Reset removes all entries of the map, but keeps its allocated capacity so that it can be reused.
+func (sm *SortedMap[K, T]) Reset() *SortedMap[K, T] {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	sm.reset()
+
+	return sm
+}
+
+// Clear is an alias of Reset.
+func (sm *SortedMap[K, T]) Clear() *SortedMap[K, T] {
+	return sm.Reset()
+}
+
+// Version returns a counter incremented on every modification of the map, it can be read without locking.
+func (sm *SortedMap[K, T]) Version() uint64 {
+	return sm.version.Load()
+}
+
+// Keys returns a copy of the keys of the map in sorted order.
 func (sm *SortedMap[K, T]) Keys() []K {
 	sm.mu.RLock()
 	defer sm.mu.RUnlock()
 
-	return sm.sortedKeys
+	return slices.Clone(sm.unsafeKeys())
 }
 
-func (sm *SortedMap[K, T]) Items() iter.Seq2[K, T] {
+// UnsafeKeys returns the internal slice of keys without copying it. The returned slice must not be modified, and it
+// may change when the map is modified.
+func (sm *SortedMap[K, T]) UnsafeKeys() []K {
 	sm.mu.RLock()
 	defer sm.mu.RUnlock()
 
+	return sm.unsafeKeys()
+}
+
+// unsafeKeys returns the internal slice of keys, the caller must hold the lock.
+func (sm *SortedMap[K, T]) unsafeKeys() []K {
+	return sm.sortedKeys
+}
+
+// Items returns an iterator over a snapshot of the map taken when the iteration starts, so the map may be modified
+// during the iteration.
+func (sm *SortedMap[K, T]) Items() iter.Seq2[K, T] {
 	return func(yield func(K, T) bool) {
-		for _, key := range sm.sortedKeys {
-			if !yield(key, sm.items[key]) {
+		sm.mu.RLock()
+		keys, values := sm.snapshot(0, len(sm.unsafeKeys()))
+		sm.mu.RUnlock()
+
+		for i, key := range keys {
+			if !yield(key, values[i]) {
 				return
 			}
 		}
 	}
 }
 
+// Values returns the values of the map in the order of their keys, Items returns the key-value pairs.
 func (sm *SortedMap[K, T]) Values() []T {
 	sm.mu.RLock()
 	defer sm.mu.RUnlock()
@@ -213,3 +868,37 @@ func (sm *SortedMap[K, T]) Values() []T {
 
 	return values
 }
+
+// ToEntries returns the entries of the map in sorted order.
+func (sm *SortedMap[K, T]) ToEntries() []Entry[K, T] {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	return sm.entries(0, len(sm.sortedKeys))
+}
+
+// Clone returns an independent copy of the map with the same validators, size limit and order, but without its
+// observers. Values are copied shallowly, so pointer values share what they point to.
+func (sm *SortedMap[K, T]) Clone() *SortedMap[K, T] {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	result := sm.newLike(len(sm.sortedKeys))
+	result.validators = slices.Clone(sm.validators)
+	result.maxSize, result.policy, result.onEvict = sm.maxSize, sm.policy, sm.onEvict
+
+	result.sortedKeys = append(result.sortedKeys, sm.sortedKeys...)
+	for key, value := range sm.items {
+		result.items[key] = value
+	}
+
+	return result
+}
+
+// ToMap returns a copy of the entries of the map as a standard map.
+func (sm *SortedMap[K, T]) ToMap() map[K]T {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	return maps.Clone(sm.items)
+}
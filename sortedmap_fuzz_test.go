@@ -0,0 +1,127 @@
+package sortedmap_test
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"golang.org/x/exp/constraints"
+)
+
+func checkIntegrity[K constraints.Ordered, T any](t *testing.T, sm *sortedmap.SortedMap[K, T]) {
+	t.Helper()
+
+	keys := sm.Keys()
+
+	if !slices.IsSorted(keys) {
+		t.Fatalf("keys are not sorted: %v", keys)
+	}
+
+	if len(slices.Compact(slices.Clone(keys))) != len(keys) {
+		t.Fatalf("keys are not unique: %v", keys)
+	}
+
+	if sm.Len() != len(keys) {
+		t.Fatalf("length %d does not match %d keys", sm.Len(), len(keys))
+	}
+
+	for _, key := range keys {
+		if !sm.Has(key) {
+			t.Fatalf("key %v is missing from the items", key)
+		}
+	}
+}
+
+// split cuts data into chunks of at most n bytes.
+func split(data []byte, n int) []string {
+	chunks := make([]string, 0, len(data)/n+1)
+
+	for chunk := range slices.Chunk(data, n) {
+		chunks = append(chunks, string(chunk))
+	}
+
+	return chunks
+}
+
+func FuzzSet(f *testing.F) {
+	f.Add([]byte("key1"), []byte("value1"))
+	f.Add([]byte(""), []byte(""))
+	f.Add([]byte("\x00\xff"), []byte("\xff"))
+
+	f.Fuzz(func(t *testing.T, key, value []byte) {
+		sm := sortedmap.New[string, string]()
+
+		for _, k := range split(key, 2) {
+			sm.Set(k, string(value))
+		}
+
+		sm.Set(string(key), string(value))
+
+		checkIntegrity(t, sm)
+
+		if sm.MustGet(string(key)) != string(value) {
+			t.Fatalf("value of %q was not stored", key)
+		}
+	})
+}
+
+func FuzzDeleteSequence(f *testing.F) {
+	f.Add([]byte("abcdef"), []byte("ace"))
+	f.Add([]byte("aaaa"), []byte("a"))
+	f.Add([]byte(""), []byte("xyz"))
+
+	f.Fuzz(func(t *testing.T, keys, deleted []byte) {
+		sm := sortedmap.New[int, int]()
+
+		for _, k := range keys {
+			sm.Set(int(k), int(k))
+		}
+
+		for _, k := range deleted {
+			sm.Delete(int(k))
+
+			if sm.Has(int(k)) {
+				t.Fatalf("key %d was not deleted", k)
+			}
+		}
+
+		checkIntegrity(t, sm)
+	})
+}
+
+func FuzzConcurrent(f *testing.F) {
+	f.Add([]byte("set and delete"), []byte("concurrently"))
+	f.Add([]byte{0, 1, 2, 3}, []byte{3, 2, 1, 0})
+
+	f.Fuzz(func(t *testing.T, a, b []byte) {
+		var wg sync.WaitGroup
+
+		sm := sortedmap.New[int, int]()
+
+		for _, ops := range [][]byte{a, b} {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				for i, op := range ops {
+					key := int(op >> 2)
+
+					switch op % 3 {
+					case 0:
+						sm.Set(key, i)
+					case 1:
+						sm.Delete(key)
+					default:
+						_, _ = sm.Get(key)
+					}
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		checkIntegrity(t, sm)
+	})
+}
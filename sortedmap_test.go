@@ -1,7 +1,9 @@
 package sortedmap_test
 
 import (
+	"errors"
 	"math/rand"
+	"sync"
 	"testing"
 	"time"
 
@@ -24,6 +26,25 @@ func TestSortedMap_SetHasAndGet(t *testing.T) {
 	assert.Equal(t, value1, actualValue)
 }
 
+func TestSortedMap_SetDefault(t *testing.T) {
+	key1, key2 := "key1", "key2"
+	value1, value2, value2b := 1, 2, -2
+
+	sm := sortedmap.New[string, int]().
+		Set(key1, value1)
+
+	actualValue, inserted := sm.SetDefault(key2, value2)
+	assert.True(t, inserted)
+	assert.Equal(t, value2, actualValue)
+
+	actualValue, inserted = sm.SetDefault(key2, value2b)
+	assert.False(t, inserted)
+	assert.Equal(t, value2, actualValue)
+
+	assert.Equal(t, []string{key1, key2}, sm.Keys())
+	assert.Equal(t, value2, sm.MustGet(key2))
+}
+
 func TestSortedMap_HasGetNonExistentKey(t *testing.T) {
 	key1 := "key1"
 
@@ -59,6 +80,140 @@ func TestSortedMap_HasAny(t *testing.T) {
 	assert.False(t, sm.HasAny(key2, key3))
 }
 
+func TestSortedMap_UpdateWithPrevious(t *testing.T) {
+	sm := sortedmap.New[string, int]().
+		Set("key1", 1)
+
+	increment := func(prev int, _ bool) (int, bool) {
+		return prev + 1, true
+	}
+
+	prev, value, existed := sm.UpdateWithPrevious("key1", increment)
+	assert.Equal(t, 1, prev)
+	assert.Equal(t, 2, value)
+	assert.True(t, existed)
+
+	prev, value, existed = sm.UpdateWithPrevious("key2", increment)
+	assert.Equal(t, 0, prev)
+	assert.Equal(t, 1, value)
+	assert.False(t, existed)
+
+	prev, value, existed = sm.UpdateWithPrevious("key1", func(prev int, _ bool) (int, bool) {
+		return 0, prev < 2
+	})
+	assert.Equal(t, 2, prev)
+	assert.Equal(t, 0, value)
+	assert.True(t, existed)
+
+	assert.Equal(t, []string{"key2"}, sm.Keys())
+}
+
+func TestSortedMap_VersionedGet(t *testing.T) {
+	sm := sortedmap.New[string, int]().
+		Set("key1", 1).
+		Set("key2", 2)
+
+	value, version, err := sm.VersionedGet("key1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, value)
+	assert.Equal(t, uint64(2), version)
+
+	sm.Delete("key2")
+
+	_, version, err = sm.VersionedGet("key2")
+	assert.ErrorIs(t, err, sortedmap.ErrKeyDoesNotExist)
+	assert.Equal(t, sm.Version(), version)
+}
+
+func TestSortedMap_Accumulate(t *testing.T) {
+	sum := func(a, b int) int {
+		return a + b
+	}
+
+	sm := sortedmap.New[string, int]().
+		Set("key1", 10).
+		Accumulate("key1", []int{1, 2, 3}, sum).
+		Accumulate("key2", []int{5}, sum).
+		Accumulate("key3", nil, sum)
+
+	assert.Equal(t, []string{"key1", "key2"}, sm.Keys())
+	assert.Equal(t, []int{16, 5}, sm.Values())
+
+	sm.AccumulateAll(map[string][]int{
+		"key2": {1, 1},
+		"key4": {4},
+	}, sum)
+
+	assert.Equal(t, []string{"key1", "key2", "key4"}, sm.Keys())
+	assert.Equal(t, []int{16, 7, 4}, sm.Values())
+}
+
+func TestSortedMap_GetMultiple(t *testing.T) {
+	sm := sortedmap.New[string, int]().
+		Set("key1", 1).
+		Set("key2", 2)
+
+	values, missing := sm.GetMultiple([]string{"key2", "nope", "key1", "other"})
+
+	assert.Equal(t, []int{2, 0, 1, 0}, values)
+	assert.Equal(t, []string{"nope", "other"}, missing)
+
+	values, missing = sm.GetMultiple(nil)
+
+	assert.Equal(t, []int{}, values)
+	assert.Equal(t, []string{}, missing)
+}
+
+func TestSortedMap_Prefetch(t *testing.T) {
+	sm := sortedmap.New[string, int]().
+		Set("key1", 1)
+
+	version := sm.Version()
+
+	sm.Prefetch([]string{"key1", "nope"})
+
+	assert.Equal(t, []string{"key1"}, sm.Keys())
+	assert.Equal(t, version, sm.Version())
+}
+
+func TestSortedMap_Reserve(t *testing.T) {
+	sm := sortedmap.New[int, int]().
+		Set(2, 2).
+		Set(1, 1).
+		Reserve(100)
+
+	assert.Equal(t, []int{1, 2}, sm.Keys())
+	assert.Equal(t, 102, sm.MemStats().KeySliceCap)
+
+	sm.Reserve(10).Reserve(-1)
+
+	assert.Equal(t, 102, sm.MemStats().KeySliceCap)
+
+	for i := range 100 {
+		sm.Set(i+10, i)
+	}
+
+	assert.Equal(t, 102, sm.Len())
+	assert.Equal(t, 102, sm.MemStats().KeySliceCap)
+}
+
+func TestSortedMap_Defragment(t *testing.T) {
+	sm := sortedmap.New[int, int]()
+	for i := range 1000 {
+		sm.Set(i, i)
+	}
+
+	for i := range 990 {
+		sm.Delete(i)
+	}
+
+	sm.Defragment()
+
+	assert.Equal(t, 10, sm.MemStats().KeySliceCap)
+	assert.Equal(t, []int{990, 991, 992, 993, 994, 995, 996, 997, 998, 999}, sm.Keys())
+	assert.Equal(t, 999, sm.MustGet(999))
+}
+
 func TestSortedMap_Delete(t *testing.T) {
 	key1, key2, key3 := "key1", "key2", "key3"
 	value1, value2, value3 := "value1", "value2", "value3"
@@ -79,6 +234,140 @@ func TestSortedMap_Delete(t *testing.T) {
 	assert.True(t, sm.Has(key3))
 }
 
+func TestSortedMap_DeleteE(t *testing.T) {
+	sm := sortedmap.New[string, int]().
+		Set("key1", 1).
+		Set("key2", 2)
+
+	_, err := sm.DeleteE("key1")
+	require.NoError(t, err)
+	assert.False(t, sm.Has("key1"))
+
+	_, err = sm.DeleteE("key1")
+	assert.ErrorIs(t, err, sortedmap.ErrKeyDoesNotExist)
+}
+
+func TestSortedMap_MustDelete(t *testing.T) {
+	sm := sortedmap.New[string, int]().
+		Set("key1", 1).
+		Set("key2", 2)
+
+	assert.Equal(t, []string{"key2"}, sm.MustDelete("key1").Keys())
+
+	assert.PanicsWithError(t, "key does not exist: key1", func() {
+		sm.MustDelete("key1")
+	})
+}
+
+func TestSortedMap_BatchDelete(t *testing.T) {
+	sm := sortedmap.New[int, int]()
+	for i := range 10 {
+		sm.Set(i, i*10)
+	}
+
+	keys := []int{8, 2, -1, 5, 2, 0, 42}
+
+	sm.BatchDelete(keys)
+
+	assert.Equal(t, []int{1, 3, 4, 6, 7, 9}, sm.Keys())
+	assert.Equal(t, []int{10, 30, 40, 60, 70, 90}, sm.Values())
+	assert.Equal(t, []int{-1, 0, 2, 2, 5, 8, 42}, keys)
+
+	sm.BatchDelete(nil)
+
+	assert.Equal(t, 6, sm.Len())
+}
+
+func TestSortedMap_Retain(t *testing.T) {
+	key1, key2, key3, key4 := "key1", "key2", "key3", "key4"
+	value1, value2, value3, value4 := "value1", "value2", "value3", "value4"
+
+	sm := sortedmap.New[string, string]().
+		Set(key2, value2).
+		Set(key1, value1).
+		Set(key4, value4).
+		Set(key3, value3)
+
+	sm.Retain(key3, key1, "nope")
+
+	assert.Equal(t, []string{key1, key3}, sm.Keys())
+	assert.Equal(t, []string{value1, value3}, sm.Values())
+	assert.False(t, sm.Has(key2))
+	assert.Equal(t, 2, sm.Len())
+
+	sm.Retain()
+
+	assert.Equal(t, 0, sm.Len())
+}
+
+func TestSortedMap_RetainIf(t *testing.T) {
+	sm := sortedmap.New[int, int]().
+		Set(1, 10).
+		Set(2, 20).
+		Set(3, 30)
+
+	sm.RetainIf(func(k, v int) bool {
+		return k != 2 && v < 30
+	})
+
+	assert.Equal(t, []int{1}, sm.Keys())
+	assert.Equal(t, []int{10}, sm.Values())
+}
+
+func TestSortedMap_Reset(t *testing.T) {
+	sm := sortedmap.NewWithCapacity[string, int](10).
+		Set("key1", 1).
+		Set("key2", 2)
+
+	version := sm.Version()
+
+	sm.Reset()
+
+	assert.Equal(t, 0, sm.Len())
+	assert.False(t, sm.Has("key1"))
+	assert.Equal(t, 10, sm.MemStats().KeySliceCap)
+	assert.Equal(t, version+1, sm.Version())
+
+	sm.Reset()
+
+	assert.Equal(t, version+1, sm.Version())
+	assert.Equal(t, []string{"key3"}, sm.Set("key3", 3).Keys())
+}
+
+func TestSortedMap_Clear(t *testing.T) {
+	sm := sortedmap.NewWithCapacity[string, int](10).
+		Set("key1", 1).
+		Set("key2", 2)
+
+	assert.Equal(t, 0, sm.Clear().Len())
+	assert.Empty(t, sm.Keys())
+	assert.Empty(t, sm.ToMap())
+	assert.Equal(t, 10, sm.MemStats().KeySliceCap)
+	assert.Equal(t, []int{3}, sm.Set("key3", 3).Values())
+}
+
+func TestSortedMap_Version(t *testing.T) {
+	sm := sortedmap.New[string, int]()
+	assert.Equal(t, uint64(0), sm.Version())
+
+	sm.Set("key1", 1).Set("key2", 2)
+	assert.Equal(t, uint64(2), sm.Version())
+
+	sm.Set("key1", -1)
+	assert.Equal(t, uint64(3), sm.Version())
+
+	sm.Delete("nope")
+	_, _ = sm.SetDefault("key1", 10)
+	_, _ = sm.Get("key1")
+	assert.Equal(t, uint64(3), sm.Version())
+
+	sm.Delete("key1")
+	assert.Equal(t, uint64(4), sm.Version())
+
+	sm.Set("key3", 3).Retain("key3")
+	assert.Equal(t, uint64(6), sm.Version())
+}
+
 func TestSortedMap_Keys(t *testing.T) {
 	key1, key2, key3 := "key1", "key2", "key3"
 	value1, value2, value3 := "value1", "value2", "value3"
@@ -144,19 +433,27 @@ func TestSortedMap_ParallelSet(t *testing.T) {
 
 	sm := sortedmap.New[string, int]()
 
+	var wg sync.WaitGroup
+
+	wg.Add(1)
 	go func() {
+		defer wg.Done()
+
 		time.Sleep(time.Duration(rand.Intn(500)) * time.Microsecond)
 
 		sm.Set(key1, value1).Set(key2, value2)
 	}()
 
+	wg.Add(1)
 	go func() {
+		defer wg.Done()
+
 		time.Sleep(time.Duration(rand.Intn(500)) * time.Microsecond)
 
 		sm.Set(key2b, value2b).Set(key3, value3)
 	}()
 
-	time.Sleep(time.Millisecond)
+	wg.Wait()
 
 	assert.Equal(t, 3, sm.Len())
 	assert.Equal(t, []string{key1, key2, key3}, sm.Keys())
@@ -168,7 +465,12 @@ func TestSortedMap_ParallelSetFloatFloat(t *testing.T) {
 
 	sm := sortedmap.New[float64, float64]()
 
+	var wg sync.WaitGroup
+
+	wg.Add(1)
 	go func() {
+		defer wg.Done()
+
 		time.Sleep(time.Duration(rand.Intn(500)) * time.Microsecond)
 
 		sm.Set(key1, value1)
@@ -178,7 +480,10 @@ func TestSortedMap_ParallelSetFloatFloat(t *testing.T) {
 		sm.Set(key2, value2)
 	}()
 
+	wg.Add(1)
 	go func() {
+		defer wg.Done()
+
 		time.Sleep(time.Duration(rand.Intn(500)) * time.Microsecond)
 
 		sm.Set(key3, value3)
@@ -188,7 +493,7 @@ func TestSortedMap_ParallelSetFloatFloat(t *testing.T) {
 		sm.Set(key2, value2b)
 	}()
 
-	time.Sleep(time.Millisecond)
+	wg.Wait()
 
 	assert.Equal(t, 3, sm.Len())
 	assert.Equal(t, []float64{key1, key2, key3}, sm.Keys())
@@ -206,19 +511,27 @@ func TestSortedMap_ParallelDelete(t *testing.T) {
 		Set(key2b, value2b).
 		Set(key3, value3)
 
+	var wg sync.WaitGroup
+
+	wg.Add(1)
 	go func() {
+		defer wg.Done()
+
 		time.Sleep(time.Duration(rand.Intn(500)) * time.Microsecond)
 
 		sm.Delete(key1)
 	}()
 
+	wg.Add(1)
 	go func() {
+		defer wg.Done()
+
 		time.Sleep(time.Duration(rand.Intn(500)) * time.Microsecond)
 
 		sm.Delete(key3)
 	}()
 
-	time.Sleep(time.Millisecond)
+	wg.Wait()
 
 	assert.Equal(t, 1, sm.Len())
 	assert.Equal(t, []string{key2}, sm.Keys())
@@ -270,3 +583,140 @@ func TestSortedMap_NewFromComplex(t *testing.T) {
 	assert.Equal(t, []string{"b1", "b2", "b3"}, actualValue.B)
 	assert.Equal(t, []int{1, 2}, actualValue.C)
 }
+
+func TestNewFromEntries(t *testing.T) {
+	entries := []sortedmap.Entry[string, int]{
+		{Key: "c", Value: 3},
+		{Key: "a", Value: 1},
+		{Key: "b", Value: 2},
+		{Key: "a", Value: 10},
+	}
+
+	sm := sortedmap.NewFromEntries(entries)
+
+	assert.Equal(t, []string{"a", "b", "c"}, sm.Keys())
+	assert.Equal(t, []int{10, 2, 3}, sm.Values())
+	assert.Equal(t, "a", entries[0].Key)
+
+	assert.Equal(t, []sortedmap.Entry[string, int]{
+		{Key: "a", Value: 10},
+		{Key: "b", Value: 2},
+		{Key: "c", Value: 3},
+	}, sm.ToEntries())
+
+	assert.Equal(t, []sortedmap.Entry[string, int]{}, sortedmap.NewFromEntries[string, int](nil).ToEntries())
+}
+
+func TestSortedMap_Keys_ReturnsCopy(t *testing.T) {
+	sm := sortedmap.New[int, string]().
+		Set(1, "one").
+		Set(2, "two").
+		Set(3, "three")
+
+	keys := sm.Keys()
+	keys[0] = 10
+
+	sm.Set(0, "zero")
+
+	assert.Equal(t, []int{0, 1, 2, 3}, sm.Keys())
+	assert.Equal(t, []int{0, 1, 2, 3}, sm.UnsafeKeys())
+}
+
+func TestSortedMap_Items_ModifyDuringIteration(t *testing.T) {
+	sm := sortedmap.New[int, string]().
+		Set(1, "one").
+		Set(2, "two")
+
+	actualKeys := make([]int, 0, 2)
+	for key := range sm.Items() {
+		actualKeys = append(actualKeys, key)
+
+		sm.Set(key+10, "more").Delete(2)
+	}
+
+	assert.Equal(t, []int{1, 2}, actualKeys)
+	assert.Equal(t, []int{1, 11, 12}, sm.Keys())
+}
+
+func TestSortedMap_Values(t *testing.T) {
+	sm := sortedmap.New[string, int]().
+		Set("key3", 3).
+		Set("key1", 1).
+		Set("key2", 2)
+
+	assert.Equal(t, []int{1, 2, 3}, sm.Values())
+	assert.Equal(t, []int{}, sortedmap.New[string, int]().Values())
+
+	descending := sortedmap.New(sortedmap.WithDescending[string, int]()).
+		Set("key1", 1).
+		Set("key2", 2)
+
+	assert.Equal(t, []int{2, 1}, descending.Values())
+}
+
+func TestNewFromMap(t *testing.T) {
+	sm := sortedmap.NewFromMap(map[string]int{"c": 3, "a": 1, "b": 2})
+
+	assert.Equal(t, []string{"a", "b", "c"}, sm.Keys())
+	assert.Equal(t, []int{1, 2, 3}, sm.Values())
+
+	assert.Equal(t, 0, sortedmap.NewFromMap[string, int](nil).Len())
+	assert.Equal(t, 0, sortedmap.NewFromMap(map[string]int{}).Len())
+}
+
+func TestSortedMap_ToMap(t *testing.T) {
+	sm := sortedmap.New[string, int]().
+		Set("b", 2).
+		Set("a", 1)
+
+	m := sm.ToMap()
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, m)
+
+	m["c"] = 3
+	assert.False(t, sm.Has("c"))
+}
+
+func TestSortedMap_Filter(t *testing.T) {
+	sm := sortedmap.New[string, int]().
+		Set("key1", 1).
+		Set("key2", 2).
+		Set("key3", 3).
+		Set("key4", 4)
+
+	even := func(_ string, value int) bool {
+		return value%2 == 0
+	}
+
+	assert.Equal(t, []string{"key2", "key4"}, sm.Filter(even).Keys())
+	assert.Equal(t, []string{"key1", "key3"}, sm.Reject(even).Keys())
+	assert.Equal(t, 4, sm.Len())
+}
+
+func TestSortedMap_Clone(t *testing.T) {
+	value := 2
+
+	sm := sortedmap.New(
+		sortedmap.WithValidator(func(_ string, value *int) error {
+			if value == nil {
+				return errors.New("nil value")
+			}
+
+			return nil
+		}),
+	).
+		Set("key2", &value).
+		Set("key1", new(int))
+
+	clone := sm.Clone()
+
+	assert.Equal(t, sm.Keys(), clone.Keys())
+	assert.Equal(t, sm.Values(), clone.Values())
+
+	clone.Delete("key1").Set("key3", new(int)).Set("key4", nil)
+	assert.Equal(t, []string{"key1", "key2"}, sm.Keys())
+	assert.Equal(t, []string{"key2", "key3"}, clone.Keys())
+
+	// values are copied shallowly
+	*clone.MustGet("key2") = 20
+	assert.Equal(t, 20, *sm.MustGet("key2"))
+}
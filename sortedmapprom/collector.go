@@ -0,0 +1,158 @@
+// Package sortedmapprom exports metrics of sorted maps to Prometheus.
+package sortedmapprom
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/exp/constraints"
+)
+
+const observerName = "sortedmapprom"
+
+// TimedMutex is a sortedmap.RWLocker measuring how long callers wait for the lock. Pass it to sortedmap.WithMutex and
+// to WithLockTimer to export the waits of a map.
+type TimedMutex struct {
+	mu      sync.RWMutex
+	observe atomic.Pointer[prometheus.Histogram]
+}
+
+// NewTimedMutex returns an unlocked TimedMutex. It only records waits once it is passed to WithLockTimer, lock-wait
+// metrics are therefore only exported for maps created with sortedmap.WithMutex(NewTimedMutex()).
+func NewTimedMutex() *TimedMutex {
+	return &TimedMutex{}
+}
+
+func (m *TimedMutex) wait(lock func()) {
+	histogram := m.observe.Load()
+	if histogram == nil {
+		lock()
+
+		return
+	}
+
+	start := time.Now()
+
+	lock()
+
+	(*histogram).Observe(time.Since(start).Seconds())
+}
+
+// Lock locks the mutex for writing, recording how long it waited.
+func (m *TimedMutex) Lock() {
+	m.wait(m.mu.Lock)
+}
+
+// Unlock unlocks the mutex for writing.
+func (m *TimedMutex) Unlock() {
+	m.mu.Unlock()
+}
+
+// RLock locks the mutex for reading, recording how long it waited.
+func (m *TimedMutex) RLock() {
+	m.wait(m.mu.RLock)
+}
+
+// RUnlock undoes a single RLock call.
+func (m *TimedMutex) RUnlock() {
+	m.mu.RUnlock()
+}
+
+// Collector implements prometheus.Collector for a map, it is also the observer counting the modifications of the map.
+type Collector[K constraints.Ordered, T any] struct {
+	sm      *sortedmap.SortedMap[K, T]
+	sets    atomic.Uint64
+	deletes atomic.Uint64
+
+	entries  *prometheus.Desc
+	setDesc  *prometheus.Desc
+	delDesc  *prometheus.Desc
+	getDesc  *prometheus.Desc
+	missDesc *prometheus.Desc
+	lockWait prometheus.Histogram
+}
+
+// Option configures a Collector.
+type Option[K constraints.Ordered, T any] func(*Collector[K, T])
+
+// WithLockTimer records the lock waits measured by mu, which must be the lock of the map, i.e. the map must be created
+// with sortedmap.WithMutex(mu). Without it, the lock wait histogram stays empty.
+func WithLockTimer[K constraints.Ordered, T any](mu *TimedMutex) Option[K, T] {
+	return func(c *Collector[K, T]) {
+		mu.observe.Store(&c.lockWait)
+	}
+}
+
+// NewPrometheusCollector returns a collector exporting the number of entries, sets, deletes, gets and missed gets of
+// sm, and the time spent waiting for its lock if WithLockTimer is used. It registers an observer on sm and enables
+// counting its reads.
+func NewPrometheusCollector[K constraints.Ordered, T any](
+	sm *sortedmap.SortedMap[K, T],
+	namespace, subsystem, name string,
+	opts ...Option[K, T],
+) *Collector[K, T] {
+	fqName := func(metric string) string {
+		return prometheus.BuildFQName(namespace, subsystem, name+"_"+metric)
+	}
+
+	c := &Collector[K, T]{
+		sm:       sm,
+		entries:  prometheus.NewDesc(fqName("entries"), "Number of entries in the map.", nil, nil),
+		setDesc:  prometheus.NewDesc(fqName("set_total"), "Number of entries set.", nil, nil),
+		delDesc:  prometheus.NewDesc(fqName("delete_total"), "Number of entries deleted.", nil, nil),
+		getDesc:  prometheus.NewDesc(fqName("get_total"), "Number of entries read.", nil, nil),
+		missDesc: prometheus.NewDesc(fqName("get_miss_total"), "Number of reads of missing keys.", nil, nil),
+		lockWait: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      name + "_lock_wait_seconds",
+			Help:      "Time spent waiting for the lock of the map.",
+			Buckets:   prometheus.ExponentialBuckets(1e-6, 10, 7),
+		}),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	sm.CountReads().Register(observerName, c)
+
+	return c
+}
+
+// OnSet counts the entries set in the map.
+func (c *Collector[K, T]) OnSet(K, T, bool, T) {
+	c.sets.Add(1)
+}
+
+// OnDelete counts the entries deleted from the map.
+func (c *Collector[K, T]) OnDelete(K, T) {
+	c.deletes.Add(1)
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector[K, T]) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.entries
+	ch <- c.setDesc
+	ch <- c.delDesc
+	ch <- c.getDesc
+	ch <- c.missDesc
+
+	c.lockWait.Describe(ch)
+}
+
+// Collect implements prometheus.Collector, reading the number of entries takes the read lock of the map.
+func (c *Collector[K, T]) Collect(ch chan<- prometheus.Metric) {
+	gets, misses := c.sm.ReadStats()
+
+	ch <- prometheus.MustNewConstMetric(c.entries, prometheus.GaugeValue, float64(c.sm.Len()))
+	ch <- prometheus.MustNewConstMetric(c.setDesc, prometheus.CounterValue, float64(c.sets.Load()))
+	ch <- prometheus.MustNewConstMetric(c.delDesc, prometheus.CounterValue, float64(c.deletes.Load()))
+	ch <- prometheus.MustNewConstMetric(c.getDesc, prometheus.CounterValue, float64(gets))
+	ch <- prometheus.MustNewConstMetric(c.missDesc, prometheus.CounterValue, float64(misses))
+
+	c.lockWait.Collect(ch)
+}
@@ -0,0 +1,84 @@
+package sortedmapprom_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/peteraba/sortedmap/sortedmapprom"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPrometheusCollector(t *testing.T) {
+	sm := sortedmap.New[string, int]()
+
+	c := sortedmapprom.NewPrometheusCollector(sm, "app", "cache", "sortedmap")
+
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(t, registry.Register(c))
+
+	sm.Set("key1", 1).Set("key2", 2).Set("key1", 10).Delete("key2")
+
+	_, _ = sm.Get("key1")
+	_, _ = sm.Get("nope")
+
+	expected := `
+# HELP app_cache_sortedmap_delete_total Number of entries deleted.
+# TYPE app_cache_sortedmap_delete_total counter
+app_cache_sortedmap_delete_total 1
+# HELP app_cache_sortedmap_entries Number of entries in the map.
+# TYPE app_cache_sortedmap_entries gauge
+app_cache_sortedmap_entries 1
+# HELP app_cache_sortedmap_get_miss_total Number of reads of missing keys.
+# TYPE app_cache_sortedmap_get_miss_total counter
+app_cache_sortedmap_get_miss_total 1
+# HELP app_cache_sortedmap_get_total Number of entries read.
+# TYPE app_cache_sortedmap_get_total counter
+app_cache_sortedmap_get_total 2
+# HELP app_cache_sortedmap_set_total Number of entries set.
+# TYPE app_cache_sortedmap_set_total counter
+app_cache_sortedmap_set_total 3
+`
+
+	err := testutil.GatherAndCompare(
+		registry,
+		strings.NewReader(expected),
+		"app_cache_sortedmap_delete_total",
+		"app_cache_sortedmap_entries",
+		"app_cache_sortedmap_get_miss_total",
+		"app_cache_sortedmap_get_total",
+		"app_cache_sortedmap_set_total",
+	)
+	assert.NoError(t, err)
+}
+
+func TestWithLockTimer(t *testing.T) {
+	mu := sortedmapprom.NewTimedMutex()
+
+	sm := sortedmap.New(sortedmap.WithMutex[string, int](mu))
+
+	c := sortedmapprom.NewPrometheusCollector(sm, "", "", "sortedmap", sortedmapprom.WithLockTimer[string, int](mu))
+
+	sm.Set("key1", 1)
+	_ = sm.Len()
+
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(t, registry.Register(c))
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() == "sortedmap_lock_wait_seconds" {
+			// registering the observer, Set, Len and the Len call of Collect
+			assert.Equal(t, uint64(4), family.GetMetric()[0].GetHistogram().GetSampleCount())
+
+			return
+		}
+	}
+
+	t.Fatal("lock wait histogram not found")
+}
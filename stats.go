@@ -0,0 +1,235 @@
+package sortedmap
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"unsafe"
+
+	"golang.org/x/exp/constraints"
+)
+
+var ErrBinsNotSorted = errors.New("bins are not sorted")
+
+func (sm *SortedMap[K, T]) histogram(bins []K) (map[K]int, error) {
+	for i := 1; i < len(bins); i++ {
+		if compareKeys(bins[i], bins[i-1], sm.direction) <= 0 {
+			return nil, ErrBinsNotSorted
+		}
+	}
+
+	counts := make(map[K]int, len(bins))
+
+	for i := 0; i+1 < len(bins); i++ {
+		lo := searchSorted(sm.sortedKeys, bins[i], sm.direction)
+		hi := searchSorted(sm.sortedKeys, bins[i+1], sm.direction)
+
+		counts[bins[i]] = hi - lo
+	}
+
+	return counts, nil
+}
+
+// Histogram counts the keys falling into each [bins[i], bins[i+1]) bin, the result is indexed by the lower bound of the
+// bins. The bins must be strictly increasing, or strictly decreasing for descending maps.
+func Histogram[K constraints.Ordered, T any](sm *SortedMap[K, T], bins []K) (map[K]int, error) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	return sm.histogram(bins)
+}
+
+// HistogramNormalized works like Histogram, but returns the proportion of all the keys of the map in each bin.
+func HistogramNormalized[K constraints.Ordered, T any](sm *SortedMap[K, T], bins []K) (map[K]float64, error) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	counts, err := sm.histogram(bins)
+	if err != nil {
+		return nil, err
+	}
+
+	proportions := make(map[K]float64, len(counts))
+	for bin, count := range counts {
+		if len(sm.sortedKeys) > 0 {
+			proportions[bin] = float64(count) / float64(len(sm.sortedKeys))
+		} else {
+			proportions[bin] = 0
+		}
+	}
+
+	return proportions, nil
+}
+
+// MedianKey returns the median key of the map, or false if the map is empty. For maps with an even number of entries
+// the lower median is returned, the key at index (Len()-1)/2.
+func (sm *SortedMap[K, T]) MedianKey() (K, bool) {
+	key, _, ok := sm.MedianEntry()
+
+	return key, ok
+}
+
+// MedianEntry works like MedianKey, but returns the value of the median key too.
+func (sm *SortedMap[K, T]) MedianEntry() (K, T, bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	return sm.entryAt((len(sm.sortedKeys) - 1) / 2)
+}
+
+// Percentile returns the key at index floor(p * (Len()-1)), p must be between 0 and 1. It returns false if the map is
+// empty or p is out of range.
+func (sm *SortedMap[K, T]) Percentile(p float64) (K, bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if !(p >= 0 && p <= 1) {
+		var zero K
+
+		return zero, false
+	}
+
+	key, _, ok := sm.entryAt(int(math.Floor(p * float64(len(sm.sortedKeys)-1))))
+
+	return key, ok
+}
+
+// KthSmallestKey returns the k-th smallest key of the map, k is 1-based. It returns false if k is out of range.
+func (sm *SortedMap[K, T]) KthSmallestKey(k int) (K, bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	return sm.kthKey(k, ascending)
+}
+
+// KthLargestKey returns the k-th largest key of the map, k is 1-based. It returns false if k is out of range.
+func (sm *SortedMap[K, T]) KthLargestKey(k int) (K, bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	return sm.kthKey(k, descending)
+}
+
+func (sm *SortedMap[K, T]) kthKey(k int, direction int8) (K, bool) {
+	if k <= 0 || k > len(sm.sortedKeys) {
+		var zero K
+
+		return zero, false
+	}
+
+	i := k - 1
+	if direction != sm.direction {
+		i = len(sm.sortedKeys) - k
+	}
+
+	key, _, ok := sm.entryAt(i)
+
+	return key, ok
+}
+
+// mapOverheadPerEntry is a rough estimate of the bytes used by the Go runtime to store an entry in a map, besides the
+// key and the value themselves.
+const mapOverheadPerEntry = 16
+
+// memStatsSampleSize is the maximum number of entries inspected to estimate the average size of string keys and values.
+const memStatsSampleSize = 16
+
+// SortedMapMemStats describes the memory used by a map, as returned by MemStats. The byte counts are estimates, they
+// do not include memory referenced by pointers, slices or other indirections in keys and values.
+type SortedMapMemStats struct {
+	// EntriesCount is the number of entries of the map.
+	EntriesCount int
+	// KeySliceLen is the length of the sorted slice of keys.
+	KeySliceLen int
+	// KeySliceCap is the capacity of the sorted slice of keys.
+	KeySliceCap int
+	// KeySliceBytes is the size of the backing array of the sorted slice of keys.
+	KeySliceBytes int
+	// MapEstimatedBytes is the estimated size of the entries stored in the underlying map, including string contents
+	// measured on a sample.
+	MapEstimatedBytes int
+}
+
+// stringBytes returns the length of v if it is a string, 0 otherwise.
+func stringBytes(v any) int {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.String {
+		return 0
+	}
+
+	return rv.Len()
+}
+
+// MemStats returns an estimate of the memory used by the map. Sizes are shallow, except for string keys and values,
+// whose average length is measured on a sample of the entries.
+func (sm *SortedMap[K, T]) MemStats() SortedMapMemStats {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	var (
+		zeroK K
+		zeroT T
+	)
+
+	entries := len(sm.sortedKeys)
+	entrySize := int(unsafe.Sizeof(zeroK)) + int(unsafe.Sizeof(zeroT)) + mapOverheadPerEntry
+
+	if samples := min(entries, memStatsSampleSize); samples > 0 {
+		var stringSize int
+
+		for i := range samples {
+			key := sm.sortedKeys[i*entries/samples]
+
+			stringSize += stringBytes(key) + stringBytes(sm.items[key])
+		}
+
+		entrySize += stringSize / samples
+	}
+
+	return SortedMapMemStats{
+		EntriesCount:      entries,
+		KeySliceLen:       len(sm.sortedKeys),
+		KeySliceCap:       cap(sm.sortedKeys),
+		KeySliceBytes:     cap(sm.sortedKeys) * int(unsafe.Sizeof(zeroK)),
+		MapEstimatedBytes: entries * entrySize,
+	}
+}
+
+// closer reports whether floor is at least as close to target as ceiling, with floor <= target <= ceiling.
+func closer[K constraints.Integer | constraints.Float](floor, target, ceiling K) bool {
+	if kind := reflect.TypeFor[K]().Kind(); kind == reflect.Float32 || kind == reflect.Float64 {
+		return float64(target)-float64(floor) <= float64(ceiling)-float64(target)
+	}
+
+	// unsigned differences can not overflow, even for signed keys
+	return uint64(target)-uint64(floor) <= uint64(ceiling)-uint64(target)
+}
+
+// Closest returns the entry with the key closest to target, or false if the map is empty. If two keys are equally
+// close, the smaller one is returned.
+func Closest[K constraints.Integer | constraints.Float, T any](sm *SortedMap[K, T], target K) (K, T, bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	i := searchSorted(sm.sortedKeys, target, sm.direction)
+
+	switch {
+	case i < len(sm.sortedKeys) && sm.sortedKeys[i] == target:
+		return sm.entryAt(i)
+	case i == 0:
+		return sm.entryAt(i)
+	case i == len(sm.sortedKeys):
+		return sm.entryAt(i - 1)
+	}
+
+	floor, ceiling := i-1, i
+	if sm.direction == descending {
+		floor, ceiling = ceiling, floor
+	}
+
+	if closer(sm.sortedKeys[floor], target, sm.sortedKeys[ceiling]) {
+		return sm.entryAt(floor)
+	}
+
+	return sm.entryAt(ceiling)
+}
@@ -0,0 +1,213 @@
+package sortedmap_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistogram(t *testing.T) {
+	sm := sortedmap.New[int, string]()
+	for _, key := range []int{-5, 0, 1, 9, 10, 11, 25, 30, 100} {
+		sm.Set(key, "")
+	}
+
+	actual, err := sortedmap.Histogram(sm, []int{0, 10, 20, 30})
+	require.NoError(t, err)
+	assert.Equal(t, map[int]int{0: 3, 10: 2, 20: 1}, actual)
+
+	normalized, err := sortedmap.HistogramNormalized(sm, []int{0, 10, 20, 30})
+	require.NoError(t, err)
+	assert.InDeltaMapValues(t, map[int]float64{0: 3.0 / 9, 10: 2.0 / 9, 20: 1.0 / 9}, normalized, 1e-9)
+}
+
+func TestHistogram_Empty(t *testing.T) {
+	sm := sortedmap.New[string, int]()
+
+	actual, err := sortedmap.Histogram(sm, []string{"a", "n"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"a": 0}, actual)
+
+	normalized, err := sortedmap.HistogramNormalized(sm, []string{"a", "n"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"a": 0}, normalized)
+}
+
+func TestHistogram_UnsortedBins(t *testing.T) {
+	sm := sortedmap.New[int, string]().Set(1, "one")
+
+	_, err := sortedmap.Histogram(sm, []int{0, 10, 5})
+	assert.ErrorIs(t, err, sortedmap.ErrBinsNotSorted)
+
+	_, err = sortedmap.HistogramNormalized(sm, []int{0, 0})
+	assert.ErrorIs(t, err, sortedmap.ErrBinsNotSorted)
+}
+
+func TestSortedMap_MedianKey(t *testing.T) {
+	sm := sortedmap.New[int, string]().
+		Set(3, "three").
+		Set(1, "one").
+		Set(2, "two")
+
+	key, ok := sm.MedianKey()
+	require.True(t, ok)
+	assert.Equal(t, 2, key)
+
+	// lower median for even sizes
+	key, value, ok := sm.Set(4, "four").MedianEntry()
+	require.True(t, ok)
+	assert.Equal(t, 2, key)
+	assert.Equal(t, "two", value)
+
+	_, ok = sortedmap.New[int, string]().MedianKey()
+	assert.False(t, ok)
+}
+
+func TestSortedMap_Percentile(t *testing.T) {
+	sm := sortedmap.New[int, string]()
+	for i := 1; i <= 11; i++ {
+		sm.Set(i*10, "")
+	}
+
+	tests := []struct {
+		name        string
+		p           float64
+		expectedKey int
+		expectedOk  bool
+	}{
+		{name: "minimum", p: 0, expectedKey: 10, expectedOk: true},
+		{name: "median", p: 0.5, expectedKey: 60, expectedOk: true},
+		{name: "p95", p: 0.95, expectedKey: 100, expectedOk: true},
+		{name: "maximum", p: 1, expectedKey: 110, expectedOk: true},
+		{name: "negative", p: -0.1, expectedOk: false},
+		{name: "above one", p: 1.1, expectedOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, ok := sm.Percentile(tt.p)
+
+			assert.Equal(t, tt.expectedOk, ok)
+			assert.Equal(t, tt.expectedKey, key)
+		})
+	}
+
+	_, ok := sortedmap.New[int, string]().Percentile(0.5)
+	assert.False(t, ok)
+}
+
+func TestSortedMap_KthKey(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []sortedmap.Option[int, string]
+	}{
+		{name: "ascending"},
+		{name: "descending", opts: []sortedmap.Option[int, string]{sortedmap.WithDescending[int, string]()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sm := sortedmap.New(tt.opts...).
+				Set(30, "").
+				Set(10, "").
+				Set(20, "")
+
+			key, ok := sm.KthSmallestKey(1)
+			require.True(t, ok)
+			assert.Equal(t, 10, key)
+
+			key, ok = sm.KthSmallestKey(3)
+			require.True(t, ok)
+			assert.Equal(t, 30, key)
+
+			key, ok = sm.KthLargestKey(1)
+			require.True(t, ok)
+			assert.Equal(t, 30, key)
+
+			key, ok = sm.KthLargestKey(2)
+			require.True(t, ok)
+			assert.Equal(t, 20, key)
+
+			_, ok = sm.KthSmallestKey(0)
+			assert.False(t, ok)
+
+			_, ok = sm.KthLargestKey(4)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestSortedMap_MemStats(t *testing.T) {
+	sm := sortedmap.NewWithCapacity[int64, int64](10).
+		Set(1, 1).
+		Set(2, 2)
+
+	assert.Equal(t, sortedmap.SortedMapMemStats{
+		EntriesCount:      2,
+		KeySliceLen:       2,
+		KeySliceCap:       10,
+		KeySliceBytes:     80,
+		MapEstimatedBytes: 2 * (8 + 8 + 16),
+	}, sm.MemStats())
+
+	words := sortedmap.New[string, string]().
+		Set("ab", "cdef").
+		Set("gh", "ij")
+
+	stats := words.MemStats()
+
+	assert.Equal(t, 2, stats.EntriesCount)
+	assert.Equal(t, 2*(16+16+16+5), stats.MapEstimatedBytes)
+
+	assert.Equal(t, sortedmap.SortedMapMemStats{}, sortedmap.NewWithCapacity[int, int](0).MemStats())
+}
+
+func TestClosest(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []sortedmap.Option[int, string]
+	}{
+		{name: "ascending"},
+		{name: "descending", opts: []sortedmap.Option[int, string]{sortedmap.WithDescending[int, string]()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sm := sortedmap.New(tt.opts...).
+				Set(10, "ten").
+				Set(20, "twenty").
+				Set(30, "thirty")
+
+			for target, expected := range map[int]int{-5: 10, 10: 10, 14: 10, 15: 10, 16: 20, 26: 30, 100: 30} {
+				key, _, ok := sortedmap.Closest(sm, target)
+				require.True(t, ok)
+				assert.Equal(t, expected, key, "target %d", target)
+			}
+
+			_, value, _ := sortedmap.Closest(sm, 21)
+			assert.Equal(t, "twenty", value)
+		})
+	}
+
+	_, _, ok := sortedmap.Closest(sortedmap.New[int, string](), 1)
+	assert.False(t, ok)
+}
+
+func TestClosest_Extremes(t *testing.T) {
+	sm := sortedmap.New[int64, string]().
+		Set(math.MinInt64, "").
+		Set(math.MaxInt64, "")
+
+	key, _, _ := sortedmap.Closest(sm, 1)
+	assert.Equal(t, int64(math.MaxInt64), key)
+
+	floats := sortedmap.New[float64, string]().
+		Set(0.1, "").
+		Set(0.3, "")
+
+	closest, _, _ := sortedmap.Closest(floats, 0.25)
+	assert.InDelta(t, 0.3, closest, 1e-9)
+}
@@ -0,0 +1,201 @@
+package sortedmap
+
+import (
+	"maps"
+	"slices"
+)
+
+// setSorted stores entries with keys sorted in the order of sm by merging them with the keys of sm in a single pass.
+// The entries must already be validated and the map must not have a size limit.
+func (sm *SortedMap[K, T]) setSorted(keys []K, values []T) {
+	merged := make([]K, 0, len(sm.sortedKeys)+len(keys))
+
+	appendKey := func(key K) {
+		merged = append(merged, key)
+	}
+
+	mergeScan(sm.sortedKeys, keys, sm.direction, appendKey, appendKey, appendKey)
+
+	sm.sortedKeys = merged
+
+	for i, key := range keys {
+		old, exists := sm.items[key]
+
+		sm.items[key] = values[i]
+
+		sm.version.Add(1)
+
+		sm.notifySet(key, old, exists, values[i])
+
+		sm.wake(key)
+	}
+}
+
+// SetManyFromMap stores the entries of m while holding the write lock once. Entries rejected by the validators or
+// because the map is full are skipped.
+func (sm *SortedMap[K, T]) SetManyFromMap(m map[K]T) *SortedMap[K, T] {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	keys := slices.SortedFunc(maps.Keys(m), func(a, b K) int {
+		return compareKeys(a, b, sm.direction)
+	})
+
+	if sm.maxSize > 0 {
+		for _, key := range keys {
+			_ = sm.set(key, m[key])
+		}
+
+		return sm
+	}
+
+	values := make([]T, 0, len(keys))
+
+	keys = slices.DeleteFunc(keys, func(key K) bool {
+		if sm.validate(key, m[key]) != nil {
+			return true
+		}
+
+		values = append(values, m[key])
+
+		return false
+	})
+
+	sm.setSorted(keys, values)
+
+	return sm
+}
+
+// FlushTo moves all entries of sm to dst while holding the write lock of both maps, entries of dst with the same keys
+// are overwritten. Entries rejected by the validators of dst or because dst is full are kept in sm.
+func (sm *SortedMap[K, T]) FlushTo(dst *SortedMap[K, T]) *SortedMap[K, T] {
+	if sm == dst {
+		return sm
+	}
+
+	unlock := lockPair(sm, true, dst, true)
+	defer unlock()
+
+	if dst.maxSize > 0 {
+		sm.retain(func(key K, value T) bool {
+			return dst.set(key, value) != nil
+		})
+
+		return sm
+	}
+
+	keys := make([]K, 0, len(sm.sortedKeys))
+	values := make([]T, 0, len(sm.sortedKeys))
+
+	sm.retain(func(key K, value T) bool {
+		if dst.validate(key, value) != nil {
+			return true
+		}
+
+		keys = append(keys, key)
+		values = append(values, value)
+
+		return false
+	})
+
+	if sm.direction != dst.direction {
+		slices.Reverse(keys)
+		slices.Reverse(values)
+	}
+
+	dst.setSorted(keys, values)
+
+	return sm
+}
+
+// CopyTo stores the entries of sm in dst while holding the read lock of sm and the write lock of dst, and returns the
+// number of keys added to dst and the number of keys of dst updated. Entries rejected by the validators of dst or
+// because dst is full are skipped. Copying a map to itself does nothing.
+func (sm *SortedMap[K, T]) CopyTo(dst *SortedMap[K, T]) (int, int) {
+	if sm == dst {
+		return 0, 0
+	}
+
+	unlock := lockPair(sm, false, dst, true)
+	defer unlock()
+
+	var added, updated int
+
+	count := func(existed bool) {
+		if existed {
+			updated++
+		} else {
+			added++
+		}
+	}
+
+	if dst.maxSize > 0 {
+		for _, key := range sm.sortedKeys {
+			existed := dst.has(key)
+
+			if dst.set(key, sm.items[key]) == nil {
+				count(existed)
+			}
+		}
+
+		return added, updated
+	}
+
+	keys := make([]K, 0, len(sm.sortedKeys))
+	values := make([]T, 0, len(sm.sortedKeys))
+
+	for _, key := range sm.sortedKeys {
+		value := sm.items[key]
+
+		if dst.validate(key, value) != nil {
+			continue
+		}
+
+		count(dst.has(key))
+
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+
+	if sm.direction != dst.direction {
+		slices.Reverse(keys)
+		slices.Reverse(values)
+	}
+
+	dst.setSorted(keys, values)
+
+	return added, updated
+}
+
+// AtomicSwap swaps the content of sm and other while holding the write lock of both maps. Validators and size limits
+// of the maps are not checked, keys are reversed in place if the maps are sorted in opposite directions.
+func (sm *SortedMap[K, T]) AtomicSwap(other *SortedMap[K, T]) *SortedMap[K, T] {
+	if sm == other {
+		return sm
+	}
+
+	unlock := lockPair(sm, true, other, true)
+	defer unlock()
+
+	smItems, smKeys := sm.items, sm.sortedKeys
+	otherItems, otherKeys := other.items, other.sortedKeys
+
+	if sm.direction != other.direction {
+		slices.Reverse(smKeys)
+		slices.Reverse(otherKeys)
+	}
+
+	sm.items, sm.sortedKeys = otherItems, otherKeys
+	other.items, other.sortedKeys = smItems, smKeys
+
+	sm.version.Add(1)
+	other.version.Add(1)
+
+	sm.notifyReplaced(smItems, smKeys)
+	other.notifyReplaced(otherItems, otherKeys)
+
+	sm.wakeAll()
+	other.wakeAll()
+
+	return sm
+}
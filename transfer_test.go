@@ -0,0 +1,161 @@
+package sortedmap_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortedMap_FlushTo(t *testing.T) {
+	src := sortedmap.New[int, string]().
+		Set(1, "one").
+		Set(3, "three").
+		Set(5, "five")
+
+	dst := sortedmap.New(sortedmap.WithDescending[int, string]()).
+		Set(2, "two").
+		Set(3, "old three")
+
+	src.FlushTo(dst)
+
+	assert.Equal(t, 0, src.Len())
+	assert.Equal(t, []int{5, 3, 2, 1}, dst.Keys())
+	assert.Equal(t, []string{"five", "three", "two", "one"}, dst.Values())
+
+	assert.Equal(t, 4, dst.FlushTo(dst).Len())
+}
+
+func TestSortedMap_FlushTo_Rejected(t *testing.T) {
+	errOdd := errors.New("odd")
+
+	src := sortedmap.New[int, int]().
+		Set(1, 1).
+		Set(2, 2).
+		Set(3, 3).
+		Set(4, 4)
+
+	dst := sortedmap.New(sortedmap.WithValidator(func(key int, _ int) error {
+		if key%2 == 1 {
+			return errOdd
+		}
+
+		return nil
+	}))
+
+	src.FlushTo(dst)
+
+	assert.Equal(t, []int{1, 3}, src.Keys())
+	assert.Equal(t, []int{2, 4}, dst.Keys())
+
+	full := sortedmap.New(sortedmap.WithMaxSize[int, int](1, nil))
+
+	src.FlushTo(full)
+
+	assert.Equal(t, []int{3}, src.Keys())
+	assert.Equal(t, []int{1}, full.Keys())
+}
+
+func TestSortedMap_AtomicSwap(t *testing.T) {
+	live := sortedmap.New[string, int]().
+		Set("key1", 1)
+
+	buffer := sortedmap.New(sortedmap.WithDescending[string, int]()).
+		Set("key2", 2).
+		Set("key3", 3)
+
+	version := live.Version()
+
+	live.AtomicSwap(buffer)
+
+	assert.Equal(t, []string{"key2", "key3"}, live.Keys())
+	assert.Equal(t, []int{2, 3}, live.Values())
+	assert.Equal(t, []string{"key1"}, buffer.Keys())
+	assert.Greater(t, live.Version(), version)
+
+	live.AtomicSwap(live)
+
+	assert.Equal(t, []string{"key2", "key3"}, live.Keys())
+}
+
+func TestSortedMap_CopyTo(t *testing.T) {
+	src := sortedmap.New[int, string]().
+		Set(1, "one").
+		Set(2, "two").
+		Set(3, "three")
+
+	dst := sortedmap.New(sortedmap.WithValidator(func(key int, _ string) error {
+		if key == 3 {
+			return errors.New("three")
+		}
+
+		return nil
+	})).
+		Set(2, "old two").
+		Set(4, "four")
+
+	added, updated := src.CopyTo(dst)
+
+	assert.Equal(t, 1, added)
+	assert.Equal(t, 1, updated)
+	assert.Equal(t, []int{1, 2, 3}, src.Keys())
+	assert.Equal(t, []int{1, 2, 4}, dst.Keys())
+	assert.Equal(t, []string{"one", "two", "four"}, dst.Values())
+
+	full := sortedmap.New(sortedmap.WithMaxSize[int, string](2, nil)).
+		Set(1, "")
+
+	added, updated = src.CopyTo(full)
+
+	assert.Equal(t, 1, added)
+	assert.Equal(t, 1, updated)
+	assert.Equal(t, []string{"one", "two"}, full.Values())
+
+	added, updated = src.CopyTo(src)
+
+	assert.Zero(t, added)
+	assert.Zero(t, updated)
+}
+
+func TestSortedMap_SetManyFromMap(t *testing.T) {
+	t.Run("merge", func(t *testing.T) {
+		sm := sortedmap.New[string, int]().
+			Set("b", 20).
+			Set("d", 4)
+
+		sm.SetManyFromMap(map[string]int{"a": 1, "b": 2, "c": 3})
+
+		assert.Equal(t, []string{"a", "b", "c", "d"}, sm.Keys())
+		assert.Equal(t, []int{1, 2, 3, 4}, sm.Values())
+
+		sm.SetManyFromMap(nil)
+		assert.Equal(t, 4, sm.Len())
+	})
+
+	t.Run("validators and descending order", func(t *testing.T) {
+		sm := sortedmap.New(
+			sortedmap.WithDescending[string, int](),
+			sortedmap.WithValidator(func(_ string, value int) error {
+				if value < 0 {
+					return errors.New("negative")
+				}
+
+				return nil
+			}),
+		)
+
+		sm.SetManyFromMap(map[string]int{"a": 1, "b": -2, "c": 3})
+
+		assert.Equal(t, []string{"c", "a"}, sm.Keys())
+		assert.Equal(t, []int{3, 1}, sm.Values())
+	})
+
+	t.Run("max size", func(t *testing.T) {
+		sm := sortedmap.New(sortedmap.WithMaxSize[string, int](2, nil))
+
+		sm.SetManyFromMap(map[string]int{"a": 1, "b": 2, "c": 3})
+
+		assert.Equal(t, 2, sm.Len())
+	})
+}
@@ -0,0 +1,31 @@
+package sortedmap
+
+import "golang.org/x/exp/constraints"
+
+// MapValues returns a new map with the keys of sm, holding fn(key, value) for each entry of sm.
+func MapValues[K constraints.Ordered, T, U any](sm *SortedMap[K, T], fn func(K, T) U) *SortedMap[K, U] {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	result := NewWithCapacity[K, U](len(sm.sortedKeys))
+	result.direction = sm.direction
+
+	for _, key := range sm.sortedKeys {
+		result.push(key, fn(key, sm.items[key]))
+	}
+
+	return result
+}
+
+// Reduce folds the entries of sm in sorted order, starting with initial and passing the accumulated value to fn.
+func Reduce[K constraints.Ordered, T, R any](sm *SortedMap[K, T], initial R, fn func(R, K, T) R) R {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	acc := initial
+	for _, key := range sm.sortedKeys {
+		acc = fn(acc, key, sm.items[key])
+	}
+
+	return acc
+}
@@ -0,0 +1,71 @@
+package sortedmap_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapValues(t *testing.T) {
+	sm := sortedmap.New[string, int]().
+		Set("b", 2).
+		Set("a", 1)
+
+	actual := sortedmap.MapValues(sm, func(key string, value int) string {
+		return key + strconv.Itoa(value)
+	})
+
+	assert.Equal(t, []string{"a", "b"}, actual.Keys())
+	assert.Equal(t, []string{"a1", "b2"}, actual.Values())
+
+	descending := sortedmap.New(sortedmap.WithDescending[string, int]()).Set("a", 1).Set("b", 2)
+
+	mapped := sortedmap.MapValues(descending, func(_ string, value int) int {
+		return value * 10
+	})
+
+	assert.Equal(t, []string{"b", "a"}, mapped.Keys())
+	assert.Equal(t, []int{20, 10}, mapped.Values())
+}
+
+func TestReduce(t *testing.T) {
+	t.Run("total", func(t *testing.T) {
+		sm := sortedmap.New[string, int]().
+			Set("b", 2).
+			Set("a", 1).
+			Set("c", 3)
+
+		actual := sortedmap.Reduce(sm, 0, func(acc int, _ string, value int) int {
+			return acc + value
+		})
+
+		assert.Equal(t, 6, actual)
+	})
+
+	t.Run("csv row", func(t *testing.T) {
+		sm := sortedmap.New[string, string]().
+			Set("2_name", "apple").
+			Set("1_id", "42").
+			Set("3_color", "red")
+
+		actual := sortedmap.Reduce(sm, "", func(acc, _, value string) string {
+			if acc == "" {
+				return value
+			}
+
+			return acc + "," + value
+		})
+
+		assert.Equal(t, "42,apple,red", actual)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		actual := sortedmap.Reduce(sortedmap.New[string, int](), 10, func(acc int, _ string, value int) int {
+			return acc + value
+		})
+
+		assert.Equal(t, 10, actual)
+	})
+}
@@ -0,0 +1,112 @@
+package sortedmap
+
+import (
+	"slices"
+
+	"golang.org/x/exp/constraints"
+)
+
+// ContainsValue reports whether any key of the map holds value. Values are not indexed, so this scans the whole map
+// in O(n).
+func ContainsValue[K constraints.Ordered, T comparable](sm *SortedMap[K, T], value T) bool {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	for _, v := range sm.items {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FindFirstKeyWithValue returns the smallest key which holds value.
+func FindFirstKeyWithValue[K constraints.Ordered, T comparable](sm *SortedMap[K, T], value T) (K, bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	for _, key := range sm.sortedKeys {
+		if sm.items[key] == value {
+			return key, true
+		}
+	}
+
+	var zero K
+
+	return zero, false
+}
+
+// UniqueValues returns the distinct values of the map in ascending order.
+func UniqueValues[K constraints.Ordered, T constraints.Ordered](sm *SortedMap[K, T]) []T {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	values := make([]T, 0, len(sm.items))
+	for _, value := range sm.items {
+		values = append(values, value)
+	}
+
+	slices.Sort(values)
+
+	return slices.Compact(values)
+}
+
+// UniqueValuesSet returns the distinct values of the map as a set.
+func UniqueValuesSet[K constraints.Ordered, T comparable](sm *SortedMap[K, T]) map[T]struct{} {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	set := make(map[T]struct{})
+	for _, value := range sm.items {
+		set[value] = struct{}{}
+	}
+
+	return set
+}
+
+// CountUnique returns the number of distinct values of the map.
+func CountUnique[K constraints.Ordered, T comparable](sm *SortedMap[K, T]) int {
+	return len(UniqueValuesSet(sm))
+}
+
+// ValueFrequency returns the number of keys holding each distinct value of the map.
+func ValueFrequency[K constraints.Ordered, T comparable](sm *SortedMap[K, T]) map[T]int {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	return valueFrequency(sm)
+}
+
+func valueFrequency[K constraints.Ordered, T comparable](sm *SortedMap[K, T]) map[T]int {
+	frequency := make(map[T]int)
+	for _, value := range sm.items {
+		frequency[value]++
+	}
+
+	return frequency
+}
+
+// Mode returns the most frequent value of the map and the number of keys holding it, or false if the map is empty.
+// Ties are broken in favour of the value found first in key order.
+func Mode[K constraints.Ordered, T comparable](sm *SortedMap[K, T]) (T, int, bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	var (
+		mode  T
+		count int
+	)
+
+	frequency := valueFrequency(sm)
+
+	for _, key := range sm.sortedKeys {
+		value := sm.items[key]
+
+		if frequency[value] > count {
+			mode, count = value, frequency[value]
+		}
+	}
+
+	return mode, count, count > 0
+}
@@ -0,0 +1,79 @@
+package sortedmap_test
+
+import (
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainsValue(t *testing.T) {
+	key1, key2 := "key1", "key2"
+	value1, value2 := "value1", "value2"
+
+	sm := sortedmap.New[string, string]().
+		Set(key1, value1).
+		Set(key2, value2)
+
+	assert.True(t, sortedmap.ContainsValue(sm, value2))
+	assert.False(t, sortedmap.ContainsValue(sm, "nope"))
+	assert.False(t, sortedmap.ContainsValue(sortedmap.New[string, string](), value1))
+}
+
+func TestFindFirstKeyWithValue(t *testing.T) {
+	key1, key2, key3 := "key1", "key2", "key3"
+	value1, value2 := "value1", "value2"
+
+	sm := sortedmap.New[string, string]().
+		Set(key3, value2).
+		Set(key1, value1).
+		Set(key2, value2)
+
+	actualKey, found := sortedmap.FindFirstKeyWithValue(sm, value2)
+	assert.True(t, found)
+	assert.Equal(t, key2, actualKey)
+
+	actualKey, found = sortedmap.FindFirstKeyWithValue(sm, "nope")
+	assert.False(t, found)
+	assert.Equal(t, "", actualKey)
+}
+
+func TestUniqueValues(t *testing.T) {
+	sm := sortedmap.New[string, int]().
+		Set("job1", 3).
+		Set("job2", 1).
+		Set("job3", 3).
+		Set("job4", 2).
+		Set("job5", 1)
+
+	assert.Equal(t, []int{1, 2, 3}, sortedmap.UniqueValues(sm))
+	assert.Equal(t, map[int]struct{}{1: {}, 2: {}, 3: {}}, sortedmap.UniqueValuesSet(sm))
+
+	assert.Empty(t, sortedmap.UniqueValues(sortedmap.New[string, int]()))
+	assert.Empty(t, sortedmap.UniqueValuesSet(sortedmap.New[string, int]()))
+}
+
+func TestValueFrequency(t *testing.T) {
+	sm := sortedmap.New[int, string]().
+		Set(1, "b").
+		Set(2, "a").
+		Set(3, "b").
+		Set(4, "c").
+		Set(5, "a")
+
+	assert.Equal(t, 3, sortedmap.CountUnique(sm))
+	assert.Equal(t, map[string]int{"a": 2, "b": 2, "c": 1}, sortedmap.ValueFrequency(sm))
+
+	mode, count, ok := sortedmap.Mode(sm)
+	assert.True(t, ok)
+	assert.Equal(t, "b", mode)
+	assert.Equal(t, 2, count)
+
+	empty := sortedmap.New[int, string]()
+
+	assert.Equal(t, 0, sortedmap.CountUnique(empty))
+	assert.Empty(t, sortedmap.ValueFrequency(empty))
+
+	_, _, ok = sortedmap.Mode(empty)
+	assert.False(t, ok)
+}
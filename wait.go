@@ -0,0 +1,83 @@
+package sortedmap
+
+import (
+	"context"
+	"slices"
+)
+
+// wake releases the goroutines waiting for key, it must be called while the write lock is held.
+func (sm *SortedMap[K, T]) wake(key K) {
+	waiters, exists := sm.waiters[key]
+	if !exists {
+		return
+	}
+
+	for _, ch := range waiters {
+		close(ch)
+	}
+
+	delete(sm.waiters, key)
+}
+
+// wakeAll releases the goroutines waiting for any key present in the map.
+func (sm *SortedMap[K, T]) wakeAll() {
+	for key := range sm.waiters {
+		if sm.has(key) {
+			sm.wake(key)
+		}
+	}
+}
+
+func (sm *SortedMap[K, T]) removeWaiter(key K, ch chan struct{}) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	waiters := slices.DeleteFunc(sm.waiters[key], func(c chan struct{}) bool {
+		return c == ch
+	})
+
+	if len(waiters) == 0 {
+		delete(sm.waiters, key)
+
+		return
+	}
+
+	sm.waiters[key] = waiters
+}
+
+// WaitForKey returns the value of key, blocking until the key is set if it is not present yet. It returns the error of
+// ctx if ctx is done before that.
+func (sm *SortedMap[K, T]) WaitForKey(ctx context.Context, key K) (T, error) {
+	if value, err := sm.Get(key); err == nil {
+		return value, nil
+	}
+
+	for {
+		sm.mu.Lock()
+
+		if value, exists := sm.items[key]; exists {
+			sm.mu.Unlock()
+
+			return value, nil
+		}
+
+		if sm.waiters == nil {
+			sm.waiters = make(map[K][]chan struct{})
+		}
+
+		ch := make(chan struct{})
+		sm.waiters[key] = append(sm.waiters[key], ch)
+
+		sm.mu.Unlock()
+
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			sm.removeWaiter(key, ch)
+
+			var zero T
+
+			return zero, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package sortedmap_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortedMap_WaitForKey(t *testing.T) {
+	sm := sortedmap.New[string, int]().
+		Set("key1", 1)
+
+	value, err := sm.WaitForKey(context.Background(), "key1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, value)
+
+	result := make(chan int)
+
+	go func() {
+		value, err := sm.WaitForKey(context.Background(), "key2")
+		assert.NoError(t, err)
+
+		result <- value
+	}()
+
+	sm.Set("key3", 3)
+
+	sm.BatchUpdate(func(draft *sortedmap.SortedMap[string, int]) {
+		draft.Set("key2", 2)
+	})
+
+	assert.Equal(t, 2, <-result)
+}
+
+func TestSortedMap_WaitForKey_Cancelled(t *testing.T) {
+	sm := sortedmap.New[string, int]()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := sm.WaitForKey(ctx, "key1")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	sm.Set("key1", 1)
+
+	value, err := sm.WaitForKey(context.Background(), "key1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, value)
+}
+
+func TestSortedMap_WaitForKey_Swap(t *testing.T) {
+	sm := sortedmap.New[string, int]()
+
+	result := make(chan int)
+
+	go func() {
+		value, err := sm.WaitForKey(context.Background(), "key1")
+		assert.NoError(t, err)
+
+		result <- value
+	}()
+
+	sm.AtomicSwap(sortedmap.New[string, int]().Set("key1", 1))
+
+	assert.Equal(t, 1, <-result)
+}
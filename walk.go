@@ -0,0 +1,171 @@
+package sortedmap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// WalkFrom calls f for each entry starting at the first key >= startKey until f returns false.
+// The read lock is held during the whole walk, so f must not modify the map.
+func (sm *SortedMap[K, T]) WalkFrom(startKey K, f func(K, T) bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	for i := searchSorted(sm.sortedKeys, startKey, sm.direction); i < len(sm.sortedKeys); i++ {
+		key := sm.sortedKeys[i]
+
+		if !f(key, sm.items[key]) {
+			return
+		}
+	}
+}
+
+// WalkFromDesc calls f for each entry in descending order starting at the last key <= startKey until f returns false.
+// The read lock is held during the whole walk, so f must not modify the map.
+func (sm *SortedMap[K, T]) WalkFromDesc(startKey K, f func(K, T) bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	for i := floorIndex(sm.sortedKeys, startKey, sm.direction); i >= 0; i-- {
+		key := sm.sortedKeys[i]
+
+		if !f(key, sm.items[key]) {
+			return
+		}
+	}
+}
+
+// IterateFromDesc is an alias of WalkFromDesc.
+func (sm *SortedMap[K, T]) IterateFromDesc(key K, f func(K, T) bool) {
+	sm.WalkFromDesc(key, f)
+}
+
+// ForEachParallel calls f for each entry of a snapshot of the map using parallelism goroutines and waits for all of
+// them to finish. The order of the calls is not deterministic and no lock is held while f runs. The errors returned by
+// f are joined.
+func (sm *SortedMap[K, T]) ForEachParallel(f func(K, T) error, parallelism int) error {
+	sm.mu.RLock()
+	keys, values := sm.snapshot(0, len(sm.sortedKeys))
+	sm.mu.RUnlock()
+
+	parallelism = max(min(parallelism, len(keys)), 1)
+
+	indexes := make(chan int)
+	errs := make([]error, len(keys))
+
+	var wg sync.WaitGroup
+
+	for range parallelism {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				errs[i] = f(keys[i], values[i])
+			}
+		}()
+	}
+
+	for i := range keys {
+		indexes <- i
+	}
+
+	close(indexes)
+
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// ForEachError calls f for each entry in sorted order and stops at the first error, which is returned wrapped with the
+// key it was returned for. The read lock is held during the whole iteration, so f must not modify the map.
+func (sm *SortedMap[K, T]) ForEachError(f func(K, T) error) error {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	for _, key := range sm.sortedKeys {
+		if err := f(key, sm.items[key]); err != nil {
+			return fmt.Errorf("key %v: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// ForEachErrorUnlocked works like ForEachError, but iterates over a snapshot of the map without holding the lock
+// while f runs, so f may take long or modify the map.
+func (sm *SortedMap[K, T]) ForEachErrorUnlocked(f func(K, T) error) error {
+	sm.mu.RLock()
+	keys, values := sm.snapshot(0, len(sm.sortedKeys))
+	sm.mu.RUnlock()
+
+	for i, key := range keys {
+		if err := f(key, values[i]); err != nil {
+			return fmt.Errorf("key %v: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// ForEach calls f for each entry of a snapshot of the map in sorted order, no lock is held while f runs.
+func (sm *SortedMap[K, T]) ForEach(f func(K, T)) *SortedMap[K, T] {
+	sm.mu.RLock()
+	keys, values := sm.snapshot(0, len(sm.sortedKeys))
+	sm.mu.RUnlock()
+
+	for i, key := range keys {
+		f(key, values[i])
+	}
+
+	return sm
+}
+
+// ForEachReverse works like ForEach, but in reverse order.
+func (sm *SortedMap[K, T]) ForEachReverse(f func(K, T)) *SortedMap[K, T] {
+	sm.mu.RLock()
+	keys, values := sm.snapshot(0, len(sm.sortedKeys))
+	sm.mu.RUnlock()
+
+	for i := len(keys) - 1; i >= 0; i-- {
+		f(keys[i], values[i])
+	}
+
+	return sm
+}
+
+func (sm *SortedMap[K, T]) scanWithContext(ctx context.Context, from, to int, f func(K, T) error) error {
+	for _, key := range sm.sortedKeys[from:to] {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := f(key, sm.items[key]); err != nil {
+			return fmt.Errorf("key %v: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// ForEachWithContext works like ForEachError, but stops and returns the error of ctx once it is done.
+func (sm *SortedMap[K, T]) ForEachWithContext(ctx context.Context, f func(K, T) error) error {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	return sm.scanWithContext(ctx, 0, len(sm.sortedKeys), f)
+}
+
+// ScanWithContext works like ForEachWithContext, but only calls f for the keys in the [lo, hi) range.
+func (sm *SortedMap[K, T]) ScanWithContext(ctx context.Context, lo, hi K, f func(K, T) error) error {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	from := searchSorted(sm.sortedKeys, lo, sm.direction)
+	to := max(searchSorted(sm.sortedKeys, hi, sm.direction), from)
+
+	return sm.scanWithContext(ctx, from, to, f)
+}
@@ -0,0 +1,332 @@
+package sortedmap_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortedMap_WalkFrom(t *testing.T) {
+	sm := sortedmap.New[string, int]().
+		Set("a", 1).
+		Set("prefix_b", 3).
+		Set("prefix_a", 2).
+		Set("z", 4)
+
+	tests := []struct {
+		name         string
+		startKey     string
+		expectedKeys []string
+	}{
+		{
+			name:         "prefix scan",
+			startKey:     "prefix_",
+			expectedKeys: []string{"prefix_a", "prefix_b"},
+		},
+		{
+			name:         "existing key",
+			startKey:     "prefix_b",
+			expectedKeys: []string{"prefix_b"},
+		},
+		{
+			name:         "after last key",
+			startKey:     "zz",
+			expectedKeys: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actualKeys := []string{}
+
+			sm.WalkFrom(tt.startKey, func(k string, _ int) bool {
+				if !strings.HasPrefix(k, tt.startKey) {
+					return false
+				}
+
+				actualKeys = append(actualKeys, k)
+
+				return true
+			})
+
+			assert.Equal(t, tt.expectedKeys, actualKeys)
+		})
+	}
+}
+
+func TestSortedMap_WalkFromDesc(t *testing.T) {
+	sm := sortedmap.New[int, string]().
+		Set(1, "one").
+		Set(3, "three").
+		Set(5, "five").
+		Set(7, "seven")
+
+	tests := []struct {
+		name           string
+		startKey       int
+		limit          int
+		expectedValues []string
+	}{
+		{
+			name:           "existing key",
+			startKey:       5,
+			limit:          10,
+			expectedValues: []string{"five", "three", "one"},
+		},
+		{
+			name:           "missing key starts at floor",
+			startKey:       6,
+			limit:          2,
+			expectedValues: []string{"five", "three"},
+		},
+		{
+			name:           "before first key",
+			startKey:       0,
+			limit:          10,
+			expectedValues: []string{},
+		},
+		{
+			name:           "after last key",
+			startKey:       100,
+			limit:          1,
+			expectedValues: []string{"seven"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actualValues := []string{}
+
+			sm.WalkFromDesc(tt.startKey, func(_ int, v string) bool {
+				actualValues = append(actualValues, v)
+
+				return len(actualValues) < tt.limit
+			})
+
+			assert.Equal(t, tt.expectedValues, actualValues)
+		})
+	}
+}
+
+func TestSortedMap_IterateFromDesc(t *testing.T) {
+	sm := sortedmap.New[int, int]()
+	for i := range 10 {
+		sm.Set(i*10, i)
+	}
+
+	visited := []int{}
+
+	sm.IterateFromDesc(55, func(k, _ int) bool {
+		visited = append(visited, k)
+
+		return len(visited) < 3
+	})
+
+	assert.Equal(t, []int{50, 40, 30}, visited)
+}
+
+func TestSortedMap_ForEachParallel(t *testing.T) {
+	sm := sortedmap.New[int, int]()
+	for i := range 100 {
+		sm.Set(i, i*2)
+	}
+
+	var (
+		mu      sync.Mutex
+		visited = make(map[int]int)
+		running atomic.Int32
+		peak    atomic.Int32
+	)
+
+	err := sm.ForEachParallel(func(k, v int) error {
+		current := running.Add(1)
+		defer running.Add(-1)
+
+		for {
+			p := peak.Load()
+			if current <= p || peak.CompareAndSwap(p, current) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond)
+
+		// the map must not be locked while f runs
+		sm.Set(k, v)
+
+		mu.Lock()
+		visited[k]++
+		mu.Unlock()
+
+		return nil
+	}, 10)
+	require.NoError(t, err)
+
+	assert.Len(t, visited, 100)
+	for k, count := range visited {
+		assert.Equal(t, 1, count, k)
+	}
+
+	assert.LessOrEqual(t, peak.Load(), int32(10))
+}
+
+func TestSortedMap_ForEachParallel_Errors(t *testing.T) {
+	err1, err2 := errors.New("err1"), errors.New("err2")
+
+	sm := sortedmap.New[string, error]().
+		Set("key1", err1).
+		Set("key2", nil).
+		Set("key3", err2)
+
+	err := sm.ForEachParallel(func(_ string, v error) error {
+		return v
+	}, 0)
+
+	assert.ErrorIs(t, err, err1)
+	assert.ErrorIs(t, err, err2)
+}
+
+func TestSortedMap_ForEachError(t *testing.T) {
+	errNegative := errors.New("negative")
+
+	sm := sortedmap.New[string, int]().
+		Set("key1", 1).
+		Set("key2", -2).
+		Set("key3", -3)
+
+	check := func(_ string, v int) error {
+		if v < 0 {
+			return errNegative
+		}
+
+		return nil
+	}
+
+	err := sm.ForEachError(check)
+	assert.ErrorIs(t, err, errNegative)
+	assert.EqualError(t, err, "key key2: negative")
+
+	err = sm.ForEachErrorUnlocked(check)
+	assert.EqualError(t, err, "key key2: negative")
+
+	sm.Delete("key2", "key3")
+
+	assert.NoError(t, sm.ForEachError(check))
+}
+
+func TestSortedMap_ForEachErrorUnlocked(t *testing.T) {
+	sm := sortedmap.New[int, int]().
+		Set(1, 1).
+		Set(2, 2)
+
+	visited := []int{}
+
+	err := sm.ForEachErrorUnlocked(func(k, v int) error {
+		visited = append(visited, k)
+
+		sm.Set(k+10, v)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{1, 2}, visited)
+	assert.Equal(t, []int{1, 2, 11, 12}, sm.Keys())
+}
+
+func TestSortedMap_ForEachWithContext(t *testing.T) {
+	sm := sortedmap.New[int, int]()
+	for i := range 10 {
+		sm.Set(i, i)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	visited := []int{}
+
+	err := sm.ForEachWithContext(ctx, func(k, _ int) error {
+		visited = append(visited, k)
+
+		if k == 2 {
+			cancel()
+		}
+
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []int{0, 1, 2}, visited)
+
+	// the read lock is released
+	sm.Set(10, 10)
+
+	err = sm.ForEachWithContext(context.Background(), func(k, _ int) error {
+		if k == 5 {
+			return errors.New("five")
+		}
+
+		return nil
+	})
+	assert.EqualError(t, err, "key 5: five")
+}
+
+func TestSortedMap_ScanWithContext(t *testing.T) {
+	sm := sortedmap.New[int, int]()
+	for i := range 10 {
+		sm.Set(i, i)
+	}
+
+	visited := []int{}
+
+	err := sm.ScanWithContext(context.Background(), 3, 6, func(k, _ int) error {
+		visited = append(visited, k)
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 4, 5}, visited)
+
+	err = sm.ScanWithContext(context.Background(), 6, 3, func(int, int) error {
+		t.Fatal("empty range must not be visited")
+
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestSortedMap_ForEach(t *testing.T) {
+	sm := sortedmap.New[string, int]().
+		Set("key3", 3).
+		Set("key1", 1).
+		Set("key2", 2)
+
+	keys := make([]string, 0, 3)
+	values := make([]int, 0, 3)
+
+	sm.ForEach(func(key string, value int) {
+		keys = append(keys, key)
+		values = append(values, value)
+
+		// the map can be modified by f
+		sm.Delete("key3")
+	})
+
+	assert.Equal(t, []string{"key1", "key2", "key3"}, keys)
+	assert.Equal(t, []int{1, 2, 3}, values)
+
+	keys = keys[:0]
+
+	sm.Set("key3", 3).ForEachReverse(func(key string, _ int) {
+		keys = append(keys, key)
+	})
+
+	assert.Equal(t, []string{"key3", "key2", "key1"}, keys)
+}
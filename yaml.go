@@ -0,0 +1,89 @@
+package sortedmap
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+var ErrInvalidYAML = errors.New("invalid YAML")
+
+// ExportYAML writes the map to w as a YAML mapping one entry at a time, keys are written in sorted order.
+func (sm *SortedMap[K, T]) ExportYAML(w io.Writer) error {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if len(sm.sortedKeys) == 0 {
+		_, err := io.WriteString(w, "{}\n")
+
+		return err
+	}
+
+	for _, key := range sm.sortedKeys {
+		// a sequence of single entry block mappings forms a single block mapping
+		data, err := yaml.Marshal(map[K]T{key: sm.items[key]})
+		if err != nil {
+			return err
+		}
+
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ImportYAML reads a YAML mapping from r and sets its entries in the map. The YAML document is decoded as a whole
+// before the map is locked, and either all of its entries are set or none of them if any of them is invalid or
+// rejected.
+func (sm *SortedMap[K, T]) ImportYAML(r io.Reader) error {
+	var doc yaml.Node
+
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		if err == io.EOF {
+			return nil
+		}
+
+		return err
+	}
+
+	node := &doc
+	if node.Kind == yaml.DocumentNode && len(node.Content) == 1 {
+		node = node.Content[0]
+	}
+
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("%w: expected mapping at line %d", ErrInvalidYAML, node.Line)
+	}
+
+	entries := make([]Entry[K, T], 0, len(node.Content)/2)
+	seen := make(map[K]struct{}, len(node.Content)/2)
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		var entry Entry[K, T]
+
+		if err := node.Content[i].Decode(&entry.Key); err != nil {
+			return err
+		}
+
+		if _, exists := seen[entry.Key]; exists {
+			return fmt.Errorf("%w: %v", ErrDuplicateKey, entry.Key)
+		}
+
+		seen[entry.Key] = struct{}{}
+
+		if err := node.Content[i+1].Decode(&entry.Value); err != nil {
+			return err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	return sm.setAll(entries)
+}
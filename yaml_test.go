@@ -0,0 +1,94 @@
+package sortedmap_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/peteraba/sortedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortedMap_ExportYAML(t *testing.T) {
+	sm := sortedmap.New[string, []int]().
+		Set("key2", []int{2, 3}).
+		Set("key1", []int{1})
+
+	var buf bytes.Buffer
+
+	require.NoError(t, sm.ExportYAML(&buf))
+	assert.Equal(t, "key1:\n    - 1\nkey2:\n    - 2\n    - 3\n", buf.String())
+
+	actual := sortedmap.New[string, []int]()
+	require.NoError(t, actual.ImportYAML(&buf))
+
+	assert.Equal(t, sm.Keys(), actual.Keys())
+	assert.Equal(t, sm.Values(), actual.Values())
+}
+
+func TestSortedMap_ExportYAML_Empty(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, sortedmap.New[int, int]().ExportYAML(&buf))
+	assert.Equal(t, "{}\n", buf.String())
+
+	actual := sortedmap.New[int, int]()
+	require.NoError(t, actual.ImportYAML(&buf))
+	assert.Equal(t, 0, actual.Len())
+}
+
+func TestSortedMap_ImportYAML(t *testing.T) {
+	sm := sortedmap.New[int, string]().
+		Set(1, "one")
+
+	require.NoError(t, sm.ImportYAML(strings.NewReader("3: three\n2: two\n1: uno\n")))
+	assert.Equal(t, []int{1, 2, 3}, sm.Keys())
+	assert.Equal(t, []string{"uno", "two", "three"}, sm.Values())
+}
+
+func TestSortedMap_ImportYAML_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expectedErr error
+	}{
+		{
+			name:  "malformed",
+			input: "key1: [1",
+		},
+		{
+			name:        "not a mapping",
+			input:       "- 1\n- 2\n",
+			expectedErr: sortedmap.ErrInvalidYAML,
+		},
+		{
+			name:        "duplicate keys",
+			input:       "1: one\n1: uno\n",
+			expectedErr: sortedmap.ErrDuplicateKey,
+		},
+		{
+			name:  "type mismatch",
+			input: "one: 1\n",
+		},
+		{
+			name:  "type mismatch after a valid entry",
+			input: "1: one\ntwo: 2\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sm := sortedmap.New[int, string]().Set(9, "nine")
+
+			err := sm.ImportYAML(strings.NewReader(tt.input))
+			require.Error(t, err)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			}
+
+			assert.Equal(t, []int{9}, sm.Keys())
+		})
+	}
+}